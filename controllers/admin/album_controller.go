@@ -0,0 +1,369 @@
+package admin
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// albumUploadLimit caps how many photos one upload request may carry.
+const albumUploadLimit = 50
+
+func FindAlbums(c *gin.Context) {
+	var albums []models.Album
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Preload("Media").Model(&models.Album{})
+	if search != "" {
+		query = query.Where("title LIKE ?", "%"+search+"%")
+	}
+	query.Count(&total)
+
+	err := query.Order("sort_order ASC, event_date DESC NULLS LAST, id DESC").Limit(limit).Offset(offset).Find(&albums).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch albums",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.PaginateResponse(c, helpers.AlbumResponses(c, albums), total, page, limit, baseURL, search, "List Data Albums")
+}
+
+func CreateAlbum(c *gin.Context) {
+	var req structs.AlbumCreateRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	eventDate, err := helpers.ParseNullableDate(req.EventDate)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"EventDate": err.Error()},
+		})
+		return
+	}
+
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
+	}
+
+	// the cover is optional, the first photo is shown when there is none
+	var uploadResult helpers.UploadResult
+	if file, uploadID, ok := helpers.RequestedUpload(c, "image"); ok && mediaID == nil {
+		uploadResult = helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "albums",
+		})
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
+	}
+
+	album := models.Album{
+		Title:       req.Title,
+		Slug:        helpers.Slugify(req.Title),
+		Description: req.Description,
+		Image:       uploadResult.FileName,
+		MediaID:     mediaID,
+		EventDate:   eventDate,
+		SortOrder:   req.SortOrder,
+	}
+
+	if err := database.DB.Create(&album).Error; err != nil {
+		helpers.RemoveUpload("albums", uploadResult.FileName)
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create album",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.SyncMediaUsage("album", album.ID, album.MediaID, "")
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "album", album.ID, nil, album)
+
+	database.DB.Preload("Media").First(&album, album.ID)
+
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Album created successfully",
+		Data:    helpers.AlbumResponse(c, album, 0, nil),
+	})
+}
+
+func FindAlbumByID(c *gin.Context) {
+	id := c.Param("id")
+	var album models.Album
+
+	if err := database.DB.Preload("Media").First(&album, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Album not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Album found",
+		Data:    helpers.AlbumResponses(c, []models.Album{album})[0],
+	})
+}
+
+func UpdateAlbum(c *gin.Context) {
+	id := c.Param("id")
+	var album models.Album
+
+	if err := database.DB.First(&album, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Album not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	before := album
+
+	var req structs.AlbumUpdateRequest
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Error",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	eventDate, err := helpers.ParseNullableDate(req.EventDate)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"EventDate": err.Error()},
+		})
+		return
+	}
+
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
+	}
+
+	oldImageName := album.Image
+
+	file, uploadID, ok := helpers.RequestedUpload(c, "image")
+	if ok {
+		uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "albums",
+		})
+
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
+
+		album.Image = uploadResult.FileName
+		album.MediaID = nil
+	} else if req.MediaID != nil {
+		// a media_id of 0 falls back to the first photo as cover
+		album.Image = ""
+		album.MediaID = mediaID
+	}
+
+	album.Title = req.Title
+	album.Slug = helpers.Slugify(req.Title)
+	album.Description = req.Description
+	album.EventDate = eventDate
+	album.SortOrder = req.SortOrder
+
+	if err := database.DB.Save(&album).Error; err != nil {
+		if ok && album.Image != "" {
+			helpers.RemoveUpload("albums", album.Image)
+		}
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update album",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if oldImageName != "" && album.Image != oldImageName {
+		helpers.RemoveUpload("albums", oldImageName)
+	}
+
+	helpers.SyncMediaUsage("album", album.ID, album.MediaID, "")
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "album", album.ID, before, album)
+
+	database.DB.Preload("Media").First(&album, album.ID)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success update album",
+		Data:    helpers.AlbumResponses(c, []models.Album{album})[0],
+	})
+}
+
+// DeleteAlbum moves the album to the trash. Its photos are kept and still
+// point at it, so a restore brings the album back whole, purging it
+// leaves them without an album.
+func DeleteAlbum(c *gin.Context) {
+	id := c.Param("id")
+	var album models.Album
+
+	if err := database.DB.First(&album, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Album not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	// its media usage stays until the trash is purged
+	if err := database.DB.Delete(&album).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete album",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "album", album.ID, album, nil)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success delete album",
+	})
+}
+
+// UploadAlbumPhotos adds every file sent as "images" to the album. Captions
+// can be sent as "captions" in the same order, the file name is used
+// otherwise. Files that fail are reported without stopping the others.
+func UploadAlbumPhotos(c *gin.Context) {
+	id := c.Param("id")
+	var album models.Album
+
+	if err := database.DB.First(&album, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Album not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["images"]) == 0 {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"Images": "At least one image is required"},
+		})
+		return
+	}
+
+	files := form.File["images"]
+	if len(files) > albumUploadLimit {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"Images": "Too many images in one request"},
+		})
+		return
+	}
+	captions := form.Value["captions"]
+
+	var lastOrder int
+	database.DB.Model(&models.Photo{}).Where("album_id = ?", album.ID).
+		Select("COALESCE(MAX(sort_order), 0)").Scan(&lastOrder)
+
+	response := structs.AlbumUploadResponse{
+		Photos: []structs.PhotoResponse{},
+		Failed: map[string]string{},
+	}
+
+	for i, file := range files {
+		uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "photos",
+		})
+		if uploadResult.Response != nil {
+			response.Failed[file.Filename] = uploadResult.Response.Message
+			continue
+		}
+
+		caption := strings.TrimSuffix(file.Filename, filepath.Ext(file.Filename))
+		if i < len(captions) && strings.TrimSpace(captions[i]) != "" {
+			caption = strings.TrimSpace(captions[i])
+		}
+
+		lastOrder++
+		photo := models.Photo{
+			Image:     uploadResult.FileName,
+			Caption:   caption,
+			AlbumID:   &album.ID,
+			SortOrder: lastOrder,
+		}
+
+		if err := database.DB.Create(&photo).Error; err != nil {
+			helpers.RemoveUpload("photos", uploadResult.FileName)
+			response.Failed[file.Filename] = "Failed to create photo"
+			continue
+		}
+
+		helpers.RecordAudit(c, helpers.AuditActionCreate, "photo", photo.ID, nil, photo)
+		response.Photos = append(response.Photos, helpers.PhotoResponse(c, photo))
+	}
+
+	if len(response.Photos) == 0 {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "No image could be uploaded",
+			Errors:  response.Failed,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Photos uploaded to album",
+		Data:    response,
+	})
+}
@@ -2,8 +2,6 @@ package admin
 
 import (
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -19,7 +17,7 @@ func FindAparaturs(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Model(&models.Aparatur{})
+	query := database.DB.Preload("Media").Model(&models.Aparatur{})
 	if search != "" {
 		query = query.Where("name LIKE ? OR position LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -50,26 +48,37 @@ func CreateAparatur(c *gin.Context) {
 		return
 	}
 
-	file, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
-			Success: false,
-			Message: "Validation Errors",
-			Errors:  map[string]string{"Image": "Image is required"},
-		})
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
 		return
 	}
 
-	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-		File:           file,
-		AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-		MaxSize:        10 << 20,
-		DestinationDir: "public/uploads/aparaturs",
-	})
+	// an image from the media library replaces the upload
+	var uploadResult helpers.UploadResult
+	if mediaID == nil {
+		file, uploadID, ok := helpers.RequestedUpload(c, "image")
+		if !ok {
+			c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Image": "Image is required"},
+			})
+			return
+		}
 
-	if uploadResult.Response != nil {
-		c.JSON(http.StatusBadRequest, uploadResult.Response)
-		return
+		uploadResult = helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "aparaturs",
+		})
+
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
 	}
 
 	aparatur := models.Aparatur{
@@ -77,11 +86,12 @@ func CreateAparatur(c *gin.Context) {
 		Position:    req.Position,
 		Description: req.Description,
 		Image:       uploadResult.FileName,
+		MediaID:     mediaID,
 	}
 
 	if err := database.DB.Create(&aparatur).Error; err != nil {
 		if uploadResult.FileName != "" {
-			os.Remove(filepath.Join("public", "uploads", "aparaturs", uploadResult.FileName))
+			helpers.RemoveUpload("aparaturs", uploadResult.FileName)
 		}
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -91,6 +101,11 @@ func CreateAparatur(c *gin.Context) {
 		return
 	}
 
+	helpers.SyncMediaUsage("aparatur", aparatur.ID, aparatur.MediaID, "")
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "aparatur", aparatur.ID, nil, aparatur)
+
+	database.DB.Preload("Media").First(&aparatur, aparatur.ID)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create aparatur",
@@ -102,7 +117,7 @@ func FindAparaturByID(c *gin.Context) {
 	id := c.Param("id")
 	var aparatur models.Aparatur
 
-	if err := database.DB.First(&aparatur, "id = ?", id).Error; err != nil {
+	if err := database.DB.Preload("Media").First(&aparatur, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Aparatur not found",
@@ -114,15 +129,7 @@ func FindAparaturByID(c *gin.Context) {
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Aparatur found",
-		Data: structs.AparaturResponse{
-			ID:          aparatur.ID,
-			Image:       aparatur.Image,
-			Name:        aparatur.Name,
-			Position:    aparatur.Position,
-			Description: aparatur.Description,
-			CreatedAt:   aparatur.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:   aparatur.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    aparaturResponse(aparatur),
 	})
 }
 
@@ -139,6 +146,8 @@ func UpdateAparatur(c *gin.Context) {
 		return
 	}
 
+	before := aparatur
+
 	var req structs.AparaturUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
@@ -149,18 +158,22 @@ func UpdateAparatur(c *gin.Context) {
 		return
 	}
 
-	oldImagePath := ""
-	if aparatur.Image != "" {
-		oldImagePath = filepath.Join("public", "uploads", "aparaturs", aparatur.Image)
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
 	}
 
-	file, err := c.FormFile("image")
-	if err == nil {
+	oldImageName := aparatur.Image
+
+	file, uploadID, ok := helpers.RequestedUpload(c, "image")
+	if ok {
 		uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-			File:           file,
-			AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-			MaxSize:        10 << 20,
-			DestinationDir: "public/uploads/aparaturs",
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "aparaturs",
 		})
 
 		if uploadResult.Response != nil {
@@ -169,6 +182,10 @@ func UpdateAparatur(c *gin.Context) {
 		}
 
 		aparatur.Image = uploadResult.FileName
+		aparatur.MediaID = nil
+	} else if mediaID != nil {
+		aparatur.Image = ""
+		aparatur.MediaID = mediaID
 	}
 
 	aparatur.Name = req.Name
@@ -176,8 +193,8 @@ func UpdateAparatur(c *gin.Context) {
 	aparatur.Description = req.Description
 
 	if err := database.DB.Save(&aparatur).Error; err != nil {
-		if file != nil && aparatur.Image != "" {
-			os.Remove(filepath.Join("public", "uploads", "aparaturs", aparatur.Image))
+		if ok && aparatur.Image != "" {
+			helpers.RemoveUpload("aparaturs", aparatur.Image)
 		}
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -187,22 +204,19 @@ func UpdateAparatur(c *gin.Context) {
 		return
 	}
 
-	if file != nil && oldImagePath != "" {
-		os.Remove(oldImagePath)
+	if oldImageName != "" && aparatur.Image != oldImageName {
+		helpers.RemoveUpload("aparaturs", oldImageName)
 	}
 
+	helpers.SyncMediaUsage("aparatur", aparatur.ID, aparatur.MediaID, "")
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "aparatur", aparatur.ID, before, aparatur)
+
+	database.DB.Preload("Media").First(&aparatur, aparatur.ID)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update product",
-		Data: structs.AparaturResponse{
-			ID:          aparatur.ID,
-			Image:       aparatur.Image,
-			Name:        aparatur.Name,
-			Position:    aparatur.Position,
-			Description: aparatur.Description,
-			CreatedAt:   aparatur.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:   aparatur.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    aparaturResponse(aparatur),
 	})
 }
 
@@ -219,11 +233,6 @@ func DeleteAparatur(c *gin.Context) {
 		return
 	}
 
-	imagePath := ""
-	if aparatur.Image != "" {
-		imagePath = filepath.Join("public", "uploads", "aparaturs", aparatur.Image)
-	}
-
 	if err := database.DB.Delete(&aparatur).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -233,19 +242,24 @@ func DeleteAparatur(c *gin.Context) {
 		return
 	}
 
-	if imagePath != "" {
-		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
-			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
-				Success: false,
-				Message: "Aparatur deleted but failed to remove image",
-				Errors:  map[string]string{"image": err.Error()},
-			})
-			return
-		}
-	}
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "aparatur", aparatur.ID, aparatur, nil)
 
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete aparatur",
 	})
 }
+
+func aparaturResponse(aparatur models.Aparatur) structs.AparaturResponse {
+	return structs.AparaturResponse{
+		ID:          aparatur.ID,
+		Image:       aparatur.Image,
+		Images:      helpers.EntityImages("aparaturs", aparatur.Image, aparatur.Media),
+		Media:       helpers.MediaSimpleResponse(aparatur.Media),
+		Name:        aparatur.Name,
+		Position:    aparatur.Position,
+		Description: aparatur.Description,
+		CreatedAt:   aparatur.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   aparatur.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
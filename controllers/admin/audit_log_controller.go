@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindAuditLogs(c *gin.Context) {
+	var auditLogs []models.AuditLog
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Model(&models.AuditLog{})
+	if search != "" {
+		query = query.Where("username LIKE ? OR entity_type LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if username := c.Query("username"); username != "" {
+		query = query.Where("username = ?", username)
+	}
+	if action := c.Query("action"); action != "" {
+		query = query.Where("action = ?", action)
+	}
+	if entityType := c.Query("entity_type"); entityType != "" {
+		query = query.Where("entity_type = ?", entityType)
+	}
+	if entityID := c.Query("entity_id"); entityID != "" {
+		query = query.Where("entity_id = ?", entityID)
+	}
+	if dateFrom := c.Query("date_from"); dateFrom != "" {
+		query = query.Where("created_at >= ?", dateFrom)
+	}
+	if dateTo := c.Query("date_to"); dateTo != "" {
+		query = query.Where("created_at < (?::date + INTERVAL '1 day')", dateTo)
+	}
+	query.Count(&total)
+
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&auditLogs).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch audit logs",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	auditLogResponses := []structs.AuditLogResponse{}
+	for _, auditLog := range auditLogs {
+		auditLogResponses = append(auditLogResponses, structs.AuditLogResponse{
+			ID:         auditLog.ID,
+			UserID:     auditLog.UserID,
+			Username:   auditLog.Username,
+			Action:     auditLog.Action,
+			EntityType: auditLog.EntityType,
+			EntityID:   auditLog.EntityID,
+			Before:     json.RawMessage(auditLog.Before),
+			After:      json.RawMessage(auditLog.After),
+			Changes:    json.RawMessage(auditLog.Changes),
+			IPAddress:  auditLog.IPAddress,
+			UserAgent:  auditLog.UserAgent,
+			CreatedAt:  auditLog.CreatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	helpers.PaginateResponse(c, auditLogResponses, total, page, limit, baseURL, search, "List Data Audit Logs")
+}
@@ -0,0 +1,225 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+const (
+	bulkActionDelete         = "delete"
+	bulkActionPublish        = "publish"
+	bulkActionUnpublish      = "unpublish"
+	bulkActionChangeCategory = "change_category"
+	bulkActionReassignAuthor = "reassign_author"
+)
+
+// bulkActions lists what each entity supports, only posts have a status and
+// a category and only content with an author can be reassigned.
+var bulkActions = map[string][]string{
+	"posts":     {bulkActionDelete, bulkActionPublish, bulkActionUnpublish, bulkActionChangeCategory, bulkActionReassignAuthor},
+	"products":  {bulkActionDelete, bulkActionReassignAuthor},
+	"pages":     {bulkActionDelete, bulkActionReassignAuthor},
+	"photos":    {bulkActionDelete},
+	"sliders":   {bulkActionDelete},
+	"aparaturs": {bulkActionDelete},
+}
+
+var errBulkForbidden = errors.New("forbidden - permission denied")
+
+// BulkAction applies one action to many rows of an entity in a single
+// transaction. Every item runs in its own savepoint and is checked against
+// the permission deleting or updating that row on its own needs, so an item
+// that is forbidden or fails, such as one that doesn't exist, is reported
+// and rolled back without undoing the others.
+func BulkAction(name string) gin.HandlerFunc {
+	entity := helpers.TrashEntities[name]
+
+	return func(c *gin.Context) {
+		var req structs.BulkActionRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		if !slices.Contains(bulkActions[name], req.Action) {
+			c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Action": "Action is not available for " + name},
+			})
+			return
+		}
+
+		switch req.Action {
+		case bulkActionChangeCategory:
+			if err := database.DB.First(&models.Category{}, req.CategoryID).Error; err != nil {
+				c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+					Success: false,
+					Message: "Validation Errors",
+					Errors:  map[string]string{"CategoryID": "Category not found"},
+				})
+				return
+			}
+		case bulkActionReassignAuthor:
+			if err := database.DB.First(&models.User{}, req.UserID).Error; err != nil {
+				c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+					Success: false,
+					Message: "Validation Errors",
+					Errors:  map[string]string{"UserID": "User not found"},
+				})
+				return
+			}
+		}
+
+		resolved, err := helpers.ResolvePermissions(c.GetString("username"))
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+				Success: false,
+				Message: "User not authenticated",
+			})
+			return
+		}
+
+		type change struct {
+			id            uint
+			before, after any
+		}
+		var changes []change
+
+		response := structs.BulkActionResponse{
+			Action:  req.Action,
+			Results: []structs.BulkItemResponse{},
+		}
+		seen := map[uint]bool{}
+
+		err = database.DB.Transaction(func(tx *gorm.DB) error {
+			for _, id := range req.IDs {
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+
+				var item change
+				err := tx.Transaction(func(tx *gorm.DB) error {
+					before := entity.Model()
+					if err := tx.First(before, id).Error; err != nil {
+						return err
+					}
+					if !resolved.Permissions[bulkItemPermission(name, req.Action)] {
+						return errBulkForbidden
+					}
+					item = change{id: id, before: before}
+
+					if req.Action == bulkActionDelete {
+						return tx.Delete(entity.Model(), id).Error
+					}
+
+					after := entity.Model()
+					if err := tx.First(after, id).Error; err != nil {
+						return err
+					}
+					if err := applyBulkAction(tx, req, after); err != nil {
+						return err
+					}
+					item.after = after
+					return tx.First(after, id).Error
+				})
+
+				result := structs.BulkItemResponse{ID: id, Success: err == nil}
+				switch {
+				case err == nil:
+					changes = append(changes, item)
+					response.Succeeded++
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					result.Error = "Not found"
+				case errors.Is(err, errBulkForbidden):
+					result.Error = "Forbidden"
+				default:
+					result.Error = err.Error()
+				}
+				if err != nil {
+					response.Failed++
+				}
+				response.Results = append(response.Results, result)
+			}
+			return nil
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to apply bulk action",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		for _, item := range changes {
+			if item.after == nil {
+				helpers.RecordAudit(c, helpers.AuditActionDelete, entity.Type, item.id, item.before, nil)
+				continue
+			}
+			helpers.RecordAudit(c, helpers.AuditActionUpdate, entity.Type, item.id, item.before, item.after)
+			switch after := item.after.(type) {
+			case *models.Post:
+				helpers.SavePostRevision(c, *after)
+			case *models.Page:
+				helpers.SavePageRevision(c, *after)
+			}
+		}
+
+		message := "Bulk action applied"
+		if response.Failed > 0 {
+			message = "Bulk action applied with errors"
+		}
+
+		c.JSON(http.StatusOK, structs.SuccessResponse{
+			Success: true,
+			Message: message,
+			Data:    response,
+		})
+	}
+}
+
+// bulkItemPermission is the permission one row of a bulk request needs, the
+// same as deleting or updating that row by itself.
+func bulkItemPermission(name, action string) string {
+	if action == bulkActionDelete {
+		return name + "-delete"
+	}
+	return name + "-update"
+}
+
+func applyBulkAction(tx *gorm.DB, req structs.BulkActionRequest, model any) error {
+	switch req.Action {
+	case bulkActionPublish:
+		post := model.(*models.Post)
+		publishedAt, err := helpers.ResolvePublishedAt(models.PostStatusPublished, "", post.PublishedAt)
+		if err != nil {
+			return err
+		}
+		return tx.Model(post).Updates(map[string]any{
+			"status":       models.PostStatusPublished,
+			"published_at": publishedAt,
+		}).Error
+	case bulkActionUnpublish:
+		return tx.Model(model).Update("status", models.PostStatusDraft).Error
+	case bulkActionChangeCategory:
+		return tx.Model(model).Update("category_id", req.CategoryID).Error
+	case bulkActionReassignAuthor:
+		return tx.Model(model).Update("user_id", req.UserID).Error
+	}
+	return nil
+}
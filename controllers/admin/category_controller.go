@@ -61,6 +61,8 @@ func CreateCategory(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "category", category.ID, nil, category)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create category",
@@ -101,6 +103,8 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	before := category
+
 	var req structs.CategoryUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
@@ -123,6 +127,8 @@ func UpdateCategory(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "category", category.ID, before, category)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update category",
@@ -152,6 +158,8 @@ func DeleteCategory(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "category", category.ID, category, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete category",
@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindComments(c *gin.Context) {
+	var comments []models.Comment
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Preload("Post").Model(&models.Comment{})
+	if search != "" {
+		query = query.Where("name LIKE ? OR content LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if postID := c.Query("post_id"); postID != "" {
+		query = query.Where("post_id = ?", postID)
+	}
+	query.Count(&total)
+
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&comments).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch comments",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	commentResponses := []structs.CommentAdminResponse{}
+	for _, comment := range comments {
+		commentResponses = append(commentResponses, commentAdminResponse(comment))
+	}
+
+	helpers.PaginateResponse(c, commentResponses, total, page, limit, baseURL, search, "List Data Comments")
+}
+
+func FindCommentByID(c *gin.Context) {
+	id := c.Param("id")
+	var comment models.Comment
+
+	if err := database.DB.Preload("Post").First(&comment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Comment not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Comment found",
+		Data:    commentAdminResponse(comment),
+	})
+}
+
+func UpdateCommentStatus(c *gin.Context) {
+	id := c.Param("id")
+	var comment models.Comment
+
+	if err := database.DB.Preload("Post").First(&comment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Comment not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	before := comment
+
+	var req structs.CommentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	comment.Status = req.Status
+	if req.Status == models.CommentStatusApproved {
+		if comment.ApprovedAt == nil {
+			now := time.Now()
+			comment.ApprovedAt = &now
+		}
+	} else {
+		comment.ApprovedAt = nil
+	}
+
+	err := database.DB.Model(&comment).Updates(map[string]any{
+		"status":      comment.Status,
+		"approved_at": comment.ApprovedAt,
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update comment",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "comment", comment.ID, before, comment)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success update comment",
+		Data:    commentAdminResponse(comment),
+	})
+}
+
+func DeleteComment(c *gin.Context) {
+	id := c.Param("id")
+	var comment models.Comment
+
+	if err := database.DB.First(&comment, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Comment not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	// replies go with their parent through the foreign key cascade
+	if err := database.DB.Delete(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete comment",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "comment", comment.ID, comment, nil)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success delete comment",
+		Data:    nil,
+	})
+}
+
+func commentAdminResponse(comment models.Comment) structs.CommentAdminResponse {
+	return structs.CommentAdminResponse{
+		ID:       comment.ID,
+		ParentID: comment.ParentID,
+		Post: structs.PostSimpleResponse{
+			ID:    comment.Post.ID,
+			Title: comment.Post.Title,
+			Slug:  comment.Post.Slug,
+		},
+		Name:       comment.Name,
+		Email:      comment.Email,
+		Content:    comment.Content,
+		Status:     comment.Status,
+		IPAddress:  comment.IPAddress,
+		UserAgent:  comment.UserAgent,
+		ApprovedAt: helpers.FormatNullableTime(comment.ApprovedAt),
+		CreatedAt:  comment.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:  comment.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
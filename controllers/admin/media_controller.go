@@ -0,0 +1,295 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindMedia(c *gin.Context) {
+	var medias []models.Media
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Preload("User").Model(&models.Media{})
+	if search != "" {
+		query = query.Where("original_name LIKE ? OR alt_text LIKE ?", "%"+search+"%", "%"+search+"%")
+	}
+	if mediaType := c.Query("type"); mediaType != "" {
+		query = query.Where("type = ?", mediaType)
+	}
+	query.Count(&total)
+
+	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&medias).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch media",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	ids := []uint{}
+	for _, media := range medias {
+		ids = append(ids, media.ID)
+	}
+
+	var counts []struct {
+		MediaID uint
+		Total   int64
+	}
+	database.DB.Model(&models.MediaUsage{}).
+		Select("media_id, COUNT(*) AS total").
+		Where("media_id IN ?", ids).
+		Group("media_id").
+		Scan(&counts)
+
+	usageCounts := map[uint]int64{}
+	for _, count := range counts {
+		usageCounts[count.MediaID] = count.Total
+	}
+
+	mediaResponses := []structs.MediaResponse{}
+	for _, media := range medias {
+		response := mediaResponse(c, media)
+		response.UsageCount = usageCounts[media.ID]
+		mediaResponses = append(mediaResponses, response)
+	}
+
+	helpers.PaginateResponse(c, mediaResponses, total, page, limit, baseURL, search, "List Data Media")
+}
+
+func CreateMedia(c *gin.Context) {
+	var req structs.MediaCreateRequest
+
+	if err := c.ShouldBind(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	userID, ok := helpers.AuthUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	file, uploadID, ok := helpers.RequestedUpload(c, "file")
+	if !ok {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"File": "File is required"},
+		})
+		return
+	}
+
+	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
+		File:         file,
+		UploadID:     uploadID,
+		AllowedTypes: helpers.MediaAllowedTypes,
+		MaxSize:      helpers.MediaMaxSize(),
+		Directory:    helpers.MediaDirectory,
+	})
+	if uploadResult.Response != nil {
+		c.JSON(http.StatusBadRequest, uploadResult.Response)
+		return
+	}
+
+	media := models.Media{
+		FileName:     uploadResult.FileName,
+		OriginalName: uploadResult.OriginalName,
+		MimeType:     uploadResult.ContentType,
+		Type:         helpers.MediaTypeFor(uploadResult.ContentType),
+		Size:         uploadResult.Size,
+		Width:        uploadResult.Width,
+		Height:       uploadResult.Height,
+		AltText:      req.AltText,
+		UserID:       userID,
+	}
+
+	if err := database.DB.Create(&media).Error; err != nil {
+		helpers.RemoveUpload(helpers.MediaDirectory, uploadResult.FileName)
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create media",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	database.DB.Preload("User").First(&media, media.ID)
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "media", media.ID, nil, media)
+
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Media created successfully",
+		Data:    mediaResponse(c, media),
+	})
+}
+
+func FindMediaByID(c *gin.Context) {
+	id := c.Param("id")
+	var media models.Media
+
+	if err := database.DB.Preload("User").Preload("Usages").First(&media, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Media not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Media found",
+		Data:    mediaResponse(c, media),
+	})
+}
+
+func UpdateMedia(c *gin.Context) {
+	id := c.Param("id")
+	var req structs.MediaUpdateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Error",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var media models.Media
+	if err := database.DB.Preload("User").Preload("Usages").First(&media, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Media not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	before := media
+	media.AltText = req.AltText
+
+	if err := database.DB.Model(&media).Update("alt_text", media.AltText).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update media",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "media", media.ID, before, media)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Media updated successfully",
+		Data:    mediaResponse(c, media),
+	})
+}
+
+func DeleteMedia(c *gin.Context) {
+	id := c.Param("id")
+	var media models.Media
+
+	if err := database.DB.Preload("Usages").First(&media, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Media not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if len(media.Usages) > 0 {
+		c.JSON(http.StatusConflict, structs.ErrorResponse{
+			Success: false,
+			Message: "Media is still in use",
+			Errors:  mediaUsageErrors(media.Usages),
+		})
+		return
+	}
+
+	if err := database.DB.Delete(&media).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete media",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "media", media.ID, media, nil)
+
+	if err := helpers.RemoveUpload(helpers.MediaDirectory, media.FileName); err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Media deleted but failed to remove file",
+			Errors:  map[string]string{"file": err.Error()},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success delete media",
+	})
+}
+
+func mediaResponse(c *gin.Context, media models.Media) structs.MediaResponse {
+	response := structs.MediaResponse{
+		ID:           media.ID,
+		FileName:     media.FileName,
+		OriginalName: media.OriginalName,
+		MimeType:     media.MimeType,
+		Type:         media.Type,
+		Size:         media.Size,
+		Width:        media.Width,
+		Height:       media.Height,
+		AltText:      media.AltText,
+		URL:          helpers.UploadURL(helpers.SiteURL(), helpers.MediaDirectory, media.FileName),
+		UsageCount:   int64(len(media.Usages)),
+		User: structs.UserSimpleResponse{
+			ID:   media.User.ID,
+			Name: media.User.Name,
+		},
+		CreatedAt: media.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: media.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+	if media.Type == models.MediaTypeImage {
+		response.Images = helpers.ImageURLs(helpers.MediaDirectory, media.FileName)
+	}
+	for _, usage := range media.Usages {
+		response.Usages = append(response.Usages, structs.MediaUsageResponse{
+			EntityType: usage.EntityType,
+			EntityID:   usage.EntityID,
+		})
+	}
+	return response
+}
+
+func mediaUsageErrors(usages []models.MediaUsage) map[string]string {
+	errors := map[string]string{}
+	for _, usage := range usages {
+		errors[fmt.Sprintf("%s_%d", usage.EntityType, usage.EntityID)] = fmt.Sprintf("Used by %s #%d", usage.EntityType, usage.EntityID)
+	}
+	return errors
+}
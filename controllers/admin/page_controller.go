@@ -17,7 +17,7 @@ func FindPages(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("user").Model(&models.Page{})
+	query := database.DB.Preload("user").Preload("Media").Model(&models.Page{})
 	if search != "" {
 		query = query.Where("title LIKE ? OR content LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -36,10 +36,14 @@ func FindPages(c *gin.Context) {
 	pageResponses := []structs.PageWithRelationResponse{}
 	for _, page := range pages {
 		pageResponses = append(pageResponses, structs.PageWithRelationResponse{
-			ID:      page.ID,
-			Title:   page.Title,
-			Slug:    page.Slug,
-			Content: page.Content,
+			ID:            page.ID,
+			Title:         page.Title,
+			Slug:          page.Slug,
+			Content:       page.Content,
+			ContentFormat: page.ContentFormat,
+			ContentHTML:   page.ContentHTML,
+			Images:        helpers.EntityImages("", "", page.Media),
+			Media:         helpers.MediaSimpleResponse(page.Media),
 			User: structs.UserSimpleResponse{
 				ID:   page.User.ID,
 				Name: page.User.Name,
@@ -83,11 +87,24 @@ func CreatePage(c *gin.Context) {
 		return
 	}
 
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
+	}
+
+	contentFormat := helpers.ResolveContentFormat(req.ContentFormat, "")
+	content, contentHTML := helpers.RenderContent(helpers.ContentTypePage, contentFormat, req.Content)
+
 	page := models.Page{
-		Title:   req.Title,
-		Slug:    helpers.Slugify(req.Title),
-		Content: req.Content,
-		UserID:  user.ID,
+		Title:         req.Title,
+		Slug:          helpers.Slugify(req.Title),
+		Content:       content,
+		ContentFormat: contentFormat,
+		ContentHTML:   contentHTML,
+		SEO:           helpers.SEOFromRequest(req.SEORequest),
+		MediaID:       mediaID,
+		UserID:        user.ID,
 	}
 
 	if err := database.DB.Create(&page).Error; err != nil {
@@ -99,18 +116,16 @@ func CreatePage(c *gin.Context) {
 		return
 	}
 
+	helpers.SavePageRevision(c, page)
+	helpers.SyncMediaUsage("page", page.ID, page.MediaID, page.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "page", page.ID, nil, page)
+
+	database.DB.Preload("Media").First(&page, page.ID)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Page created successfully",
-		Data: structs.PageResponse{
-			ID:        page.ID,
-			Title:     page.Title,
-			Slug:      page.Slug,
-			Content:   page.Content,
-			UserID:    page.UserID,
-			CreatedAt: page.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: page.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    pageResponse(page),
 	})
 }
 
@@ -118,7 +133,7 @@ func FindPageByID(c *gin.Context) {
 	id := c.Param("id")
 	var page models.Page
 
-	if err := database.DB.First(&page, "id = ?", id).Error; err != nil {
+	if err := database.DB.Preload("Media").First(&page, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Page not found",
@@ -130,15 +145,7 @@ func FindPageByID(c *gin.Context) {
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Page found",
-		Data: structs.PageResponse{
-			ID:        page.ID,
-			Title:     page.Title,
-			Slug:      page.Slug,
-			Content:   page.Content,
-			UserID:    page.UserID,
-			CreatedAt: page.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: page.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    pageResponse(page),
 	})
 }
 
@@ -165,9 +172,23 @@ func UpdatePage(c *gin.Context) {
 		return
 	}
 
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
+	}
+
+	before := page
+
+	// a media_id of 0 removes the page's image
+	if req.MediaID != nil {
+		page.MediaID = mediaID
+	}
 	page.Title = req.Title
 	page.Slug = helpers.Slugify(req.Title)
-	page.Content = req.Content
+	page.ContentFormat = helpers.ResolveContentFormat(req.ContentFormat, page.ContentFormat)
+	page.Content, page.ContentHTML = helpers.RenderContent(helpers.ContentTypePage, page.ContentFormat, req.Content)
+	page.SEO = helpers.SEOFromRequest(req.SEORequest)
 
 	if err := database.DB.Save(&page).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
@@ -178,18 +199,16 @@ func UpdatePage(c *gin.Context) {
 		return
 	}
 
+	helpers.SavePageRevision(c, page)
+	helpers.SyncMediaUsage("page", page.ID, page.MediaID, page.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "page", page.ID, before, page)
+
+	database.DB.Preload("Media").First(&page, page.ID)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update page",
-		Data: structs.PageResponse{
-			ID:        page.ID,
-			Title:     page.Title,
-			Slug:      page.Slug,
-			Content:   page.Content,
-			UserID:    page.UserID,
-			CreatedAt: page.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: page.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    pageResponse(page),
 	})
 }
 
@@ -215,9 +234,28 @@ func DeletePage(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "page", page.ID, page, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete post",
 		Data:    nil,
 	})
 }
+
+func pageResponse(page models.Page) structs.PageResponse {
+	return structs.PageResponse{
+		ID:            page.ID,
+		Title:         page.Title,
+		Slug:          page.Slug,
+		Content:       page.Content,
+		ContentFormat: page.ContentFormat,
+		ContentHTML:   page.ContentHTML,
+		Images:        helpers.EntityImages("", "", page.Media),
+		Media:         helpers.MediaSimpleResponse(page.Media),
+		UserID:        page.UserID,
+		SEO:           helpers.SEOResponse(page.SEO),
+		CreatedAt:     page.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     page.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -61,6 +61,8 @@ func CreatePermission(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "permission", permission.ID, nil, permission)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create permission",
@@ -101,6 +103,8 @@ func UpdatePermission(c *gin.Context) {
 		return
 	}
 
+	before := permission
+
 	var req structs.PermissionUpdateRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -123,6 +127,9 @@ func UpdatePermission(c *gin.Context) {
 		return
 	}
 
+	helpers.FlushPermissionCache()
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "permission", permission.ID, before, permission)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update permission",
@@ -161,6 +168,9 @@ func DeletePermission(c *gin.Context) {
 		return
 	}
 
+	helpers.FlushPermissionCache()
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "permission", permission.ID, permission, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete permission",
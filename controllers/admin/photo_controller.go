@@ -2,8 +2,6 @@ package admin
 
 import (
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -23,6 +21,9 @@ func FindPhotos(c *gin.Context) {
 	if search != "" {
 		query = query.Where("caption LIKE ?", "%"+search+"%")
 	}
+	if albumID := c.Query("album_id"); albumID != "" {
+		query = query.Where("album_id = ?", albumID)
+	}
 	query.Count(&total)
 
 	err := query.Order("id desc").Limit(limit).Offset(offset).Find(&photos).Error
@@ -50,8 +51,20 @@ func CreatePhoto(c *gin.Context) {
 		return
 	}
 
-	file, err := c.FormFile("image")
-	if err != nil {
+	if req.AlbumID != nil {
+		var album models.Album
+		if err := database.DB.First(&album, *req.AlbumID).Error; err != nil {
+			c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"AlbumID": "Album not found"},
+			})
+			return
+		}
+	}
+
+	file, uploadID, ok := helpers.RequestedUpload(c, "image")
+	if !ok {
 		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
 			Success: false,
 			Message: "Validation Errors",
@@ -61,10 +74,11 @@ func CreatePhoto(c *gin.Context) {
 	}
 
 	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-		File:           file,
-		AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-		MaxSize:        10 << 20,
-		DestinationDir: "public/uploads/photos",
+		File:         file,
+		UploadID:     uploadID,
+		AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+		MaxSize:      10 << 20,
+		Directory:    "photos",
 	})
 
 	if uploadResult.Response != nil {
@@ -76,11 +90,13 @@ func CreatePhoto(c *gin.Context) {
 		Image:       uploadResult.FileName,
 		Caption:     req.Caption,
 		Description: req.Description,
+		AlbumID:     req.AlbumID,
+		SortOrder:   req.SortOrder,
 	}
 
 	if err := database.DB.Create(&photo).Error; err != nil {
 		if uploadResult.FileName != "" {
-			os.Remove(filepath.Join("public", "uploads", "photos", uploadResult.FileName))
+			helpers.RemoveUpload("photos", uploadResult.FileName)
 		}
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -90,6 +106,8 @@ func CreatePhoto(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "photo", photo.ID, nil, photo)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create photo",
@@ -97,11 +115,14 @@ func CreatePhoto(c *gin.Context) {
 	})
 }
 
-func DeletePhoto(c *gin.Context) {
+// UpdatePhoto changes the caption of a photo and moves it into another
+// album, or out of any album when album_id is left empty. The image itself
+// stays.
+func UpdatePhoto(c *gin.Context) {
 	id := c.Param("id")
 	var photo models.Photo
 
-	if err := database.DB.First(&photo, id).Error; err != nil {
+	if err := database.DB.First(&photo, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Photo not found",
@@ -110,31 +131,77 @@ func DeletePhoto(c *gin.Context) {
 		return
 	}
 
-	imagePath := ""
-	if photo.Image != "" {
-		imagePath = filepath.Join("public", "uploads", "photos", photo.Image)
-	}
+	before := photo
 
-	if err := database.DB.Delete(&photo).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+	var req structs.PhotoUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
 			Success: false,
-			Message: "Failed to delete photo",
+			Message: "Validation Error",
 			Errors:  helpers.TranslateErrorMessage(err),
 		})
 		return
 	}
 
-	if imagePath != "" {
-		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
-			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+	if req.AlbumID != nil {
+		var album models.Album
+		if err := database.DB.First(&album, *req.AlbumID).Error; err != nil {
+			c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
 				Success: false,
-				Message: "Photo deleted but failed to remove image",
-				Errors:  map[string]string{"image": err.Error()},
+				Message: "Validation Errors",
+				Errors:  map[string]string{"AlbumID": "Album not found"},
 			})
 			return
 		}
 	}
 
+	photo.Caption = req.Caption
+	photo.Description = req.Description
+	photo.AlbumID = req.AlbumID
+	photo.SortOrder = req.SortOrder
+
+	if err := database.DB.Save(&photo).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update photo",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "photo", photo.ID, before, photo)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success update photo",
+		Data:    helpers.PhotoResponse(c, photo),
+	})
+}
+
+func DeletePhoto(c *gin.Context) {
+	id := c.Param("id")
+	var photo models.Photo
+
+	if err := database.DB.First(&photo, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Photo not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if err := database.DB.Delete(&photo).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete photo",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "photo", photo.ID, photo, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete photo",
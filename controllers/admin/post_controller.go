@@ -2,8 +2,7 @@ package admin
 
 import (
 	"net/http"
-	"os"
-	"path/filepath"
+	"time"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -19,10 +18,17 @@ func FindPosts(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("Category").Preload("User").Model(&models.Post{})
+	query := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").Model(&models.Post{})
 	if search != "" {
 		query = query.Where("title LIKE ?", "%"+search+"%")
 	}
+	switch status := c.Query("status"); status {
+	case "":
+	case "scheduled":
+		query = query.Where("status = ? AND published_at > ?", models.PostStatusPublished, time.Now())
+	default:
+		query = query.Where("status = ?", status)
+	}
 	query.Count(&total)
 
 	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&posts).Error
@@ -38,11 +44,17 @@ func FindPosts(c *gin.Context) {
 	postResponses := []structs.PostWithRelationResponse{}
 	for _, post := range posts {
 		postResponses = append(postResponses, structs.PostWithRelationResponse{
-			ID:      post.ID,
-			Image:   post.Image,
-			Title:   post.Title,
-			Slug:    post.Slug,
-			Content: post.Content,
+			ID:            post.ID,
+			Image:         post.Image,
+			Images:        helpers.EntityImages("posts", post.Image, post.Media),
+			Media:         helpers.MediaSimpleResponse(post.Media),
+			Title:         post.Title,
+			Slug:          post.Slug,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			ContentHTML:   post.ContentHTML,
+			Status:        post.Status,
+			PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
 			Category: structs.CategorySimpleResponse{
 				ID:   post.Category.ID,
 				Name: post.Category.Name,
@@ -51,6 +63,7 @@ func FindPosts(c *gin.Context) {
 				ID:   post.User.ID,
 				Name: post.User.Name,
 			},
+			Tags:      helpers.TagSimpleResponses(post.Tags),
 			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -71,25 +84,36 @@ func CreatePost(c *gin.Context) {
 		return
 	}
 
-	file, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
-			Success: false,
-			Message: "Validation Errors",
-			Errors:  map[string]string{"Image": "Image is required"},
-		})
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
 		return
 	}
 
-	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-		File:           file,
-		AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-		MaxSize:        10 << 20,
-		DestinationDir: "public/uploads/posts",
-	})
-	if uploadResult.Response != nil {
-		c.JSON(http.StatusBadRequest, uploadResult.Response)
-		return
+	// an image from the media library replaces the upload
+	var uploadResult helpers.UploadResult
+	if mediaID == nil {
+		file, uploadID, ok := helpers.RequestedUpload(c, "image")
+		if !ok {
+			c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Image": "Image is required"},
+			})
+			return
+		}
+
+		uploadResult = helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "posts",
+		})
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
 	}
 
 	username, ok := c.Get("username")
@@ -111,13 +135,48 @@ func CreatePost(c *gin.Context) {
 		return
 	}
 
+	if req.Status == "" {
+		req.Status = models.PostStatusDraft
+	}
+
+	publishedAt, err := helpers.ResolvePublishedAt(req.Status, req.PublishedAt, nil)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"PublishedAt": err.Error()},
+		})
+		return
+	}
+
+	tags, err := helpers.ResolveTags(database.DB, req.Tags)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create post",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	contentFormat := helpers.ResolveContentFormat(req.ContentFormat, "")
+	content, contentHTML := helpers.RenderContent(helpers.ContentTypePost, contentFormat, req.Content)
+
 	post := models.Post{
-		Title:      req.Title,
-		Slug:       helpers.Slugify(req.Title),
-		Content:    req.Content,
-		Image:      uploadResult.FileName,
-		CategoryID: req.CategoryID,
-		UserID:     user.ID,
+		Title:         req.Title,
+		Slug:          helpers.Slugify(req.Title),
+		Content:       content,
+		ContentFormat: contentFormat,
+		ContentHTML:   contentHTML,
+		SEO:           helpers.SEOFromRequest(req.SEORequest),
+		Image:         uploadResult.FileName,
+		ImageSize:     uploadResult.Size,
+		MediaID:       mediaID,
+		Status:        req.Status,
+		PublishedAt:   publishedAt,
+		CategoryID:    req.CategoryID,
+		UserID:        user.ID,
+		Tags:          tags,
 	}
 
 	if err := database.DB.Create(&post).Error; err != nil {
@@ -129,20 +188,16 @@ func CreatePost(c *gin.Context) {
 		return
 	}
 
+	helpers.SavePostRevision(c, post)
+	helpers.SyncMediaUsage("post", post.ID, post.MediaID, post.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "post", post.ID, nil, post)
+
+	database.DB.Preload("Media").First(&post, post.ID)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Post created successfully",
-		Data: structs.PostResponse{
-			ID:         post.ID,
-			Image:      post.Image,
-			Title:      post.Title,
-			Slug:       post.Slug,
-			Content:    post.Content,
-			CategoryID: post.CategoryID,
-			UserID:     post.UserID,
-			CreatedAt:  post.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:  post.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    postResponse(post),
 	})
 }
 
@@ -150,7 +205,7 @@ func FindPostByID(c *gin.Context) {
 	id := c.Param("id")
 	var post models.Post
 
-	err := database.DB.Preload("Category").Preload("User").First(&post, "id = ?", id).Error
+	err := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").First(&post, "id = ?", id).Error
 	if err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
@@ -163,17 +218,7 @@ func FindPostByID(c *gin.Context) {
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Post found",
-		Data: structs.PostResponse{
-			ID:         post.ID,
-			Image:      post.Image,
-			Title:      post.Title,
-			Slug:       post.Slug,
-			Content:    post.Content,
-			CategoryID: post.CategoryID,
-			UserID:     post.UserID,
-			CreatedAt:  post.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:  post.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    postResponse(post),
 	})
 }
 
@@ -181,7 +226,7 @@ func UpdatePost(c *gin.Context) {
 	id := c.Param("id")
 	var post models.Post
 
-	if err := database.DB.First(&post, "id = ?", id).Error; err != nil {
+	if err := database.DB.Preload("Tags").First(&post, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Post not found",
@@ -190,6 +235,8 @@ func UpdatePost(c *gin.Context) {
 		return
 	}
 
+	before := post
+
 	var req structs.PostUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
@@ -200,18 +247,20 @@ func UpdatePost(c *gin.Context) {
 		return
 	}
 
-	oldImagePath := ""
-	if post.Image != "" {
-		oldImagePath = filepath.Join("public", "uploads", "posts", post.Image)
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
 	}
 
-	file, err := c.FormFile("image")
-	if err == nil {
+	file, uploadID, ok := helpers.RequestedUpload(c, "image")
+	if ok {
 		uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-			File:           file,
-			AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-			MaxSize:        10 << 20,
-			DestinationDir: "public/uploads/posts",
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "posts",
 		})
 
 		if uploadResult.Response != nil {
@@ -220,17 +269,56 @@ func UpdatePost(c *gin.Context) {
 		}
 
 		post.Image = uploadResult.FileName
+		post.ImageSize = uploadResult.Size
+		post.MediaID = nil
+	} else if mediaID != nil {
+		post.Image = ""
+		post.ImageSize = 0
+		post.MediaID = mediaID
+	}
+
+	if req.Status != "" {
+		post.Status = req.Status
+	}
+
+	publishedAt, err := helpers.ResolvePublishedAt(post.Status, req.PublishedAt, post.PublishedAt)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"PublishedAt": err.Error()},
+		})
+		return
 	}
 
 	post.Title = req.Title
 	post.Slug = helpers.Slugify(req.Title)
-	post.Content = req.Content
+	post.ContentFormat = helpers.ResolveContentFormat(req.ContentFormat, post.ContentFormat)
+	post.Content, post.ContentHTML = helpers.RenderContent(helpers.ContentTypePost, post.ContentFormat, req.Content)
+	post.SEO = helpers.SEOFromRequest(req.SEORequest)
+	post.PublishedAt = publishedAt
 	post.CategoryID = req.CategoryID
 
+	var tags []models.Tag
+	if req.Tags != nil {
+		tags, err = helpers.ResolveTags(database.DB, req.Tags)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to update post",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+	}
+
+	// posts saved before revisions existed get one of their current state,
+	// which keeps the image being replaced until it is pruned
+	helpers.EnsurePostRevision(before)
+
 	if err := database.DB.Save(&post).Error; err != nil {
-		if file != nil && post.Image != "" {
-			newImagePath := filepath.Join("public", "uploads", "posts", post.Image)
-			os.Remove(newImagePath)
+		if ok && post.Image != "" {
+			helpers.RemoveUpload("posts", post.Image)
 		}
 
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
@@ -241,26 +329,22 @@ func UpdatePost(c *gin.Context) {
 		return
 	}
 
-	if file != nil && oldImagePath != "" {
-		os.Remove(oldImagePath)
+	if req.Tags != nil {
+		database.DB.Model(&post).Association("Tags").Replace(tags)
 	}
 
-	database.DB.Preload("Category").Preload("User").First(&post, post.ID)
+	// the previous image stays while a revision points at it, it goes
+	// once that revision is pruned
+	helpers.SavePostRevision(c, post)
+	helpers.SyncMediaUsage("post", post.ID, post.MediaID, post.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "post", post.ID, before, post)
+
+	database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").First(&post, post.ID)
 
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update post",
-		Data: structs.PostResponse{
-			ID:         post.ID,
-			Image:      post.Image,
-			Title:      post.Title,
-			Slug:       post.Slug,
-			Content:    post.Content,
-			CategoryID: post.CategoryID,
-			UserID:     post.UserID,
-			CreatedAt:  post.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt:  post.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    postResponse(post),
 	})
 }
 
@@ -277,11 +361,6 @@ func DeletPost(c *gin.Context) {
 		return
 	}
 
-	imagePath := ""
-	if post.Image != "" {
-		imagePath = filepath.Join("public", "uploads", "posts", post.Image)
-	}
-
 	if err := database.DB.Delete(&post).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -291,20 +370,33 @@ func DeletPost(c *gin.Context) {
 		return
 	}
 
-	if imagePath != "" {
-		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
-			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
-				Success: false,
-				Message: "Post deleted but failed to remove image",
-				Errors:  map[string]string{"image": "Failed to remove image file: " + err.Error()},
-			})
-			return
-		}
-	}
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "post", post.ID, post, nil)
 
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete post",
-		Data: nil,
+		Data:    nil,
 	})
 }
+
+func postResponse(post models.Post) structs.PostResponse {
+	return structs.PostResponse{
+		ID:            post.ID,
+		Image:         post.Image,
+		Images:        helpers.EntityImages("posts", post.Image, post.Media),
+		Media:         helpers.MediaSimpleResponse(post.Media),
+		Title:         post.Title,
+		Slug:          post.Slug,
+		Content:       post.Content,
+		ContentFormat: post.ContentFormat,
+		ContentHTML:   post.ContentHTML,
+		Status:        post.Status,
+		PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
+		CategoryID:    post.CategoryID,
+		UserID:        post.UserID,
+		Tags:          helpers.TagSimpleResponses(post.Tags),
+		SEO:           helpers.SEOResponse(post.SEO),
+		CreatedAt:     post.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:     post.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
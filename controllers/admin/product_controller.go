@@ -2,8 +2,6 @@ package admin
 
 import (
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -19,7 +17,7 @@ func FindProducts(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("User").Model(&models.Product{})
+	query := database.DB.Preload("User").Preload("Media").Model(&models.Product{})
 
 	if search != "" {
 		query = query.Where("title LIKE ? OR owner LIKE ?", "%"+search+"%", "%"+search+"%")
@@ -43,6 +41,8 @@ func FindProducts(c *gin.Context) {
 			Title:   product.Title,
 			Slug:    product.Slug,
 			Image:   product.Image,
+			Images:  helpers.EntityImages("products", product.Image, product.Media),
+			Media:   helpers.MediaSimpleResponse(product.Media),
 			Owner:   product.Owner,
 			Price:   product.Price,
 			Phone:   product.Phone,
@@ -71,25 +71,36 @@ func CreateProduct(c *gin.Context) {
 		return
 	}
 
-	file, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
-			Success: false,
-			Message: "Validation Errors",
-			Errors:  map[string]string{"Image": "Image is required"},
-		})
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
 		return
 	}
 
-	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-		File:           file,
-		AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-		MaxSize:        10 << 20,
-		DestinationDir: "public/uploads/products",
-	})
-	if uploadResult.Response != nil {
-		c.JSON(http.StatusBadRequest, uploadResult.Response)
-		return
+	// an image from the media library replaces the upload
+	var uploadResult helpers.UploadResult
+	if mediaID == nil {
+		file, uploadID, ok := helpers.RequestedUpload(c, "image")
+		if !ok {
+			c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Image": "Image is required"},
+			})
+			return
+		}
+
+		uploadResult = helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "products",
+		})
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
 	}
 
 	username, ok := c.Get("username")
@@ -113,9 +124,11 @@ func CreateProduct(c *gin.Context) {
 
 	product := models.Product{
 		Image:   uploadResult.FileName,
+		MediaID: mediaID,
 		Title:   req.Title,
 		Slug:    helpers.Slugify(req.Title),
-		Content: req.Content,
+		Content: helpers.SanitizeContent(helpers.ContentTypeProduct, req.Content),
+		SEO:     helpers.SEOFromRequest(req.SEORequest),
 		Owner:   req.Owner,
 		Price:   req.Price,
 		Phone:   req.Phone,
@@ -132,22 +145,15 @@ func CreateProduct(c *gin.Context) {
 		return
 	}
 
+	helpers.SyncMediaUsage("product", product.ID, product.MediaID, product.Content)
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "product", product.ID, nil, product)
+
+	database.DB.Preload("Media").First(&product, product.ID)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create product",
-		Data: structs.ProductResponse{
-			ID:        product.ID,
-			Title:     product.Title,
-			Slug:      product.Slug,
-			Content:   product.Content,
-			Image:     product.Image,
-			Owner:     product.Owner,
-			Price:     product.Price,
-			Address:   product.Address,
-			Phone:     product.Phone,
-			CreatedAt: product.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: product.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    productResponse(product),
 	})
 }
 
@@ -155,7 +161,7 @@ func FindProductByID(c *gin.Context) {
 	id := c.Param("id")
 	var product models.Product
 
-	err := database.DB.First(&product, "id = ?", id).Error
+	err := database.DB.Preload("Media").First(&product, "id = ?", id).Error
 	if err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
@@ -168,19 +174,7 @@ func FindProductByID(c *gin.Context) {
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Product found",
-		Data: structs.ProductResponse{
-			ID:        product.ID,
-			Title:     product.Title,
-			Slug:      product.Slug,
-			Content:   product.Content,
-			Image:     product.Image,
-			Owner:     product.Owner,
-			Price:     product.Price,
-			Phone:     product.Phone,
-			Address:   product.Address,
-			CreatedAt: product.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: product.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    productResponse(product),
 	})
 }
 
@@ -197,6 +191,8 @@ func UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	before := product
+
 	var req structs.ProductUpdateRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
@@ -207,18 +203,22 @@ func UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	oldImagePath := ""
-	if product.Image != "" {
-		oldImagePath = filepath.Join("public", "uploads", "products", product.Image)
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
+		return
 	}
 
-	file, err := c.FormFile("image")
-	if err == nil {
+	oldImageName := product.Image
+
+	file, uploadID, ok := helpers.RequestedUpload(c, "image")
+	if ok {
 		uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-			File:           file,
-			AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-			MaxSize:        10 << 20,
-			DestinationDir: "public/uploads/products",
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "products",
 		})
 
 		if uploadResult.Response != nil {
@@ -227,18 +227,23 @@ func UpdateProduct(c *gin.Context) {
 		}
 
 		product.Image = uploadResult.FileName
+		product.MediaID = nil
+	} else if mediaID != nil {
+		product.Image = ""
+		product.MediaID = mediaID
 	}
 
 	product.Title = req.Title
-	product.Content = req.Content
+	product.Content = helpers.SanitizeContent(helpers.ContentTypeProduct, req.Content)
+	product.SEO = helpers.SEOFromRequest(req.SEORequest)
 	product.Owner = req.Owner
 	product.Price = req.Price
 	product.Phone = req.Phone
 	product.Address = req.Address
 
 	if err := database.DB.Save(&product).Error; err != nil {
-		if file != nil && product.Image != "" {
-			os.Remove(filepath.Join("public", "uploads", "products", product.Image))
+		if ok && product.Image != "" {
+			helpers.RemoveUpload("products", product.Image)
 		}
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -248,26 +253,19 @@ func UpdateProduct(c *gin.Context) {
 		return
 	}
 
-	if file != nil && oldImagePath != "" {
-		os.Remove(oldImagePath)
+	if oldImageName != "" && product.Image != oldImageName {
+		helpers.RemoveUpload("products", oldImageName)
 	}
 
+	helpers.SyncMediaUsage("product", product.ID, product.MediaID, product.Content)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "product", product.ID, before, product)
+
+	database.DB.Preload("Media").First(&product, product.ID)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update product",
-		Data: structs.ProductResponse{
-			ID:        product.ID,
-			Title:     product.Title,
-			Slug:      product.Slug,
-			Content:   product.Content,
-			Image:     product.Image,
-			Owner:     product.Owner,
-			Price:     product.Price,
-			Address:   product.Address,
-			Phone:     product.Phone,
-			CreatedAt: product.CreatedAt.Format("2006-01-02 15:04:05"),
-			UpdatedAt: product.UpdatedAt.Format("2006-01-02 15:04:05"),
-		},
+		Data:    productResponse(product),
 	})
 }
 
@@ -284,11 +282,6 @@ func DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	imagePath := ""
-	if product.Image != "" {
-		imagePath = filepath.Join("public", "uploads", "products", product.Image)
-	}
-
 	if err := database.DB.Delete(&product).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -298,19 +291,29 @@ func DeleteProduct(c *gin.Context) {
 		return
 	}
 
-	if imagePath != "" {
-		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
-			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
-				Success: false,
-				Message: "Product deleted, but failed to delete image",
-				Errors:  map[string]string{"image": err.Error()},
-			})
-			return
-		}
-	}
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "product", product.ID, product, nil)
 
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete product",
 	})
 }
+
+func productResponse(product models.Product) structs.ProductResponse {
+	return structs.ProductResponse{
+		ID:        product.ID,
+		Title:     product.Title,
+		Slug:      product.Slug,
+		Content:   product.Content,
+		Image:     product.Image,
+		Images:    helpers.EntityImages("products", product.Image, product.Media),
+		Media:     helpers.MediaSimpleResponse(product.Media),
+		Owner:     product.Owner,
+		Price:     product.Price,
+		Address:   product.Address,
+		Phone:     product.Phone,
+		SEO:       helpers.SEOResponse(product.SEO),
+		CreatedAt: product.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt: product.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,356 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/storage"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindPostRevisions(c *gin.Context) {
+	findRevisions(c, helpers.RevisionEntityPost, &models.Post{})
+}
+
+func FindPostRevisionByID(c *gin.Context) {
+	findRevisionByID(c, helpers.RevisionEntityPost, &models.Post{})
+}
+
+func DiffPostRevisions(c *gin.Context) {
+	diffRevisions(c, helpers.RevisionEntityPost, &models.Post{})
+}
+
+func FindPageRevisions(c *gin.Context) {
+	findRevisions(c, helpers.RevisionEntityPage, &models.Page{})
+}
+
+func FindPageRevisionByID(c *gin.Context) {
+	findRevisionByID(c, helpers.RevisionEntityPage, &models.Page{})
+}
+
+func DiffPageRevisions(c *gin.Context) {
+	diffRevisions(c, helpers.RevisionEntityPage, &models.Page{})
+}
+
+func RestorePostRevision(c *gin.Context) {
+	id := c.Param("id")
+	var post models.Post
+
+	if err := database.DB.Preload("Tags").First(&post, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Post not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	revision, ok := loadRevision(c, helpers.RevisionEntityPost, post.ID, c.Param("revision_id"))
+	if !ok {
+		return
+	}
+
+	before := post
+
+	post.Title = revision.Title
+	post.Slug = helpers.Slugify(revision.Title)
+	post.ContentFormat = helpers.ResolveContentFormat(revision.ContentFormat, models.ContentFormatHTML)
+	post.Content, post.ContentHTML = helpers.RenderContent(helpers.ContentTypePost, post.ContentFormat, revision.Content)
+	if revision.CategoryID != nil {
+		post.CategoryID = *revision.CategoryID
+	}
+	// the image is only brought back while its file or media is still
+	// stored, otherwise the current one stays
+	switch {
+	case revision.Image != "":
+		if object, err := storage.Default.Stat(storage.Key("posts", revision.Image)); err == nil {
+			post.Image = revision.Image
+			post.ImageSize = object.Size
+			post.MediaID = nil
+		}
+	case revision.MediaID != nil:
+		if _, err := helpers.FindImageMedia(*revision.MediaID); err == nil {
+			post.Image = ""
+			post.ImageSize = 0
+			post.MediaID = revision.MediaID
+		}
+	default:
+		post.Image = ""
+		post.ImageSize = 0
+		post.MediaID = nil
+	}
+
+	if err := database.DB.Save(&post).Error; err != nil {
+		if helpers.IsDuplicateKey(err) {
+			c.JSON(http.StatusConflict, structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to restore revision",
+				Errors:  map[string]string{"Slug": "Another item already uses this slug"},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to restore revision",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.SavePostRevision(c, post)
+	helpers.SyncMediaUsage("post", post.ID, post.MediaID, post.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "post", post.ID, before, post)
+
+	database.DB.Preload("Media").First(&post, post.ID)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success restore revision",
+		Data:    postResponse(post),
+	})
+}
+
+func RestorePageRevision(c *gin.Context) {
+	id := c.Param("id")
+	var page models.Page
+
+	if err := database.DB.First(&page, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Page not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	revision, ok := loadRevision(c, helpers.RevisionEntityPage, page.ID, c.Param("revision_id"))
+	if !ok {
+		return
+	}
+
+	before := page
+
+	page.Title = revision.Title
+	page.Slug = helpers.Slugify(revision.Title)
+	page.ContentFormat = helpers.ResolveContentFormat(revision.ContentFormat, models.ContentFormatHTML)
+	page.Content, page.ContentHTML = helpers.RenderContent(helpers.ContentTypePage, page.ContentFormat, revision.Content)
+
+	if err := database.DB.Save(&page).Error; err != nil {
+		if helpers.IsDuplicateKey(err) {
+			c.JSON(http.StatusConflict, structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to restore revision",
+				Errors:  map[string]string{"Slug": "Another item already uses this slug"},
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to restore revision",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.SavePageRevision(c, page)
+	helpers.SyncMediaUsage("page", page.ID, page.MediaID, page.ContentHTML)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "page", page.ID, before, page)
+
+	database.DB.Preload("Media").First(&page, page.ID)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success restore revision",
+		Data:    pageResponse(page),
+	})
+}
+
+func findRevisions(c *gin.Context, entityType string, entity any) {
+	id := c.Param("id")
+
+	if err := database.DB.First(entity, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Data not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var revisions []models.Revision
+	err := database.DB.Preload("User").
+		Where("entity_type = ? AND entity_id = ?", entityType, id).
+		Order("id DESC").
+		Find(&revisions).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch revisions",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	revisionResponses := []structs.RevisionResponse{}
+	for _, revision := range revisions {
+		response := revisionResponse(revision)
+		response.Content = ""
+		revisionResponses = append(revisionResponses, response)
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "List Data Revisions",
+		Data:    revisionResponses,
+	})
+}
+
+func findRevisionByID(c *gin.Context, entityType string, entity any) {
+	id := c.Param("id")
+
+	if err := database.DB.First(entity, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Data not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	revision, ok := loadRevision(c, entityType, id, c.Param("revision_id"))
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Revision found",
+		Data:    revisionResponse(revision),
+	})
+}
+
+func diffRevisions(c *gin.Context, entityType string, entity any) {
+	id := c.Param("id")
+
+	if err := database.DB.First(entity, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Data not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	fromID := c.Query("from")
+	if fromID == "" {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"from": "from is required"},
+		})
+		return
+	}
+
+	from, ok := loadRevision(c, entityType, id, fromID)
+	if !ok {
+		return
+	}
+
+	var to models.Revision
+	if toID := c.Query("to"); toID != "" {
+		if to, ok = loadRevision(c, entityType, id, toID); !ok {
+			return
+		}
+	} else {
+		// compare against the latest revision, which mirrors the live version
+		err := database.DB.Preload("User").
+			Where("entity_type = ? AND entity_id = ?", entityType, id).
+			Order("id DESC").
+			First(&to).Error
+		if err != nil {
+			c.JSON(http.StatusNotFound, structs.ErrorResponse{
+				Success: false,
+				Message: "Revision not found",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+	}
+
+	fields := map[string]structs.RevisionFieldDiff{
+		"title":   revisionFieldDiff(from.Title, to.Title),
+		"content": revisionFieldDiff(from.Content, to.Content),
+	}
+	if entityType == helpers.RevisionEntityPost {
+		fields["image"] = revisionFieldDiff(from.Image, to.Image)
+		fields["media_id"] = revisionFieldDiff(formatOptionalID(from.MediaID), formatOptionalID(to.MediaID))
+		fields["category_id"] = revisionFieldDiff(formatOptionalID(from.CategoryID), formatOptionalID(to.CategoryID))
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Revision diff",
+		Data: structs.RevisionDiffResponse{
+			From:   revisionResponse(from),
+			To:     revisionResponse(to),
+			Fields: fields,
+		},
+	})
+}
+
+func loadRevision(c *gin.Context, entityType string, entityID any, revisionID string) (models.Revision, bool) {
+	var revision models.Revision
+
+	err := database.DB.Preload("User").
+		Where("id = ? AND entity_type = ? AND entity_id = ?", revisionID, entityType, entityID).
+		First(&revision).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Revision not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return revision, false
+	}
+
+	return revision, true
+}
+
+func revisionResponse(revision models.Revision) structs.RevisionResponse {
+	return structs.RevisionResponse{
+		ID:            revision.ID,
+		EntityType:    revision.EntityType,
+		EntityID:      revision.EntityID,
+		Title:         revision.Title,
+		Content:       revision.Content,
+		ContentFormat: revision.ContentFormat,
+		Image:         revision.Image,
+		MediaID:       revision.MediaID,
+		CategoryID:    revision.CategoryID,
+		User: structs.UserSimpleResponse{
+			ID:   revision.User.ID,
+			Name: revision.User.Name,
+		},
+		CreatedAt: revision.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func revisionFieldDiff(from, to string) structs.RevisionFieldDiff {
+	return structs.RevisionFieldDiff{
+		Changed: from != to,
+		Lines:   helpers.DiffLines(from, to),
+	}
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return fmt.Sprint(*id)
+}
@@ -89,6 +89,8 @@ func CreateRole(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "role", role.ID, nil, role)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create role",
@@ -138,7 +140,7 @@ func UpdateRole(c *gin.Context) {
 	id := c.Param("id")
 	var role models.Role
 
-	if err := database.DB.Where("id = ?", id).First(&role).Error; err != nil {
+	if err := database.DB.Preload("Permissions").Where("id = ?", id).First(&role).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Role not found",
@@ -147,6 +149,8 @@ func UpdateRole(c *gin.Context) {
 		return
 	}
 
+	before := role
+
 	var req structs.RoleUpdateRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -162,7 +166,7 @@ func UpdateRole(c *gin.Context) {
 
 	var permissions []models.Permission
 	if len(req.PermissionIDs) > 0 {
-		database.DB.Where("id IN = ?", req.PermissionIDs).Find(&permissions)
+		database.DB.Where("id IN ?", req.PermissionIDs).Find(&permissions)
 	}
 	database.DB.Model(&role).Association("Permissions").Replace(&permissions)
 
@@ -175,6 +179,9 @@ func UpdateRole(c *gin.Context) {
 		return
 	}
 
+	helpers.FlushPermissionCache()
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "role", role.ID, before, role)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update role",
@@ -213,6 +220,9 @@ func DeleteRole(c *gin.Context) {
 		return
 	}
 
+	helpers.FlushPermissionCache()
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "role", role.ID, role, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete role",
@@ -2,8 +2,6 @@ package admin
 
 import (
 	"net/http"
-	"os"
-	"path/filepath"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -19,7 +17,7 @@ func FindSliders(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Model(&models.Slider{})
+	query := database.DB.Preload("Media").Model(&models.Slider{})
 	if search != "" {
 		query = query.Where("description LIKE ?", "%"+search+"%")
 	}
@@ -50,36 +48,48 @@ func CreateSlider(c *gin.Context) {
 		return
 	}
 
-	file, err := c.FormFile("image")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
-			Success: false,
-			Message: "Validation Errors",
-			Errors:  map[string]string{"Image": "Image is required"},
-		})
+	mediaID, errResponse := helpers.ResolveImageMedia(req.MediaID)
+	if errResponse != nil {
+		c.JSON(http.StatusUnprocessableEntity, errResponse)
 		return
 	}
 
-	uploadResult := helpers.UploadFile(c, helpers.UploadConfig{
-		File:           file,
-		AllowedTypes:   []string{".jpg", ".jpeg", ".png", ".gif"},
-		MaxSize:        10 << 20,
-		DestinationDir: "public/uploads/sliders",
-	})
+	// an image from the media library replaces the upload
+	var uploadResult helpers.UploadResult
+	if mediaID == nil {
+		file, uploadID, ok := helpers.RequestedUpload(c, "image")
+		if !ok {
+			c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Image": "Image is required"},
+			})
+			return
+		}
 
-	if uploadResult.Response != nil {
-		c.JSON(http.StatusBadRequest, uploadResult.Response)
-		return
+		uploadResult = helpers.UploadFile(c, helpers.UploadConfig{
+			File:         file,
+			UploadID:     uploadID,
+			AllowedTypes: []string{".jpg", ".jpeg", ".png", ".gif"},
+			MaxSize:      10 << 20,
+			Directory:    "sliders",
+		})
+
+		if uploadResult.Response != nil {
+			c.JSON(http.StatusBadRequest, uploadResult.Response)
+			return
+		}
 	}
 
 	slider := models.Slider{
 		Image:       uploadResult.FileName,
+		MediaID:     mediaID,
 		Description: req.Description,
 	}
 
 	if err := database.DB.Create(&slider).Error; err != nil {
 		if uploadResult.FileName != "" {
-			os.Remove(filepath.Join("public", "uploads", "sliders", uploadResult.FileName))
+			helpers.RemoveUpload("sliders", uploadResult.FileName)
 		}
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -89,6 +99,11 @@ func CreateSlider(c *gin.Context) {
 		return
 	}
 
+	helpers.SyncMediaUsage("slider", slider.ID, slider.MediaID, "")
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "slider", slider.ID, nil, slider)
+
+	database.DB.Preload("Media").First(&slider, slider.ID)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create slider",
@@ -109,11 +124,7 @@ func DeleteSlider(c *gin.Context) {
 		return
 	}
 
-	imagePath := ""
-	if slider.Image != "" {
-		imagePath = filepath.Join("public", "uploads", "sliders", slider.Image)
-	}
-
+	// its media usage stays until the trash is purged
 	if err := database.DB.Delete(&slider).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -123,19 +134,22 @@ func DeleteSlider(c *gin.Context) {
 		return
 	}
 
-	if imagePath != "" {
-		if err := os.Remove(imagePath); err != nil && !os.IsNotExist(err) {
-			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
-				Success: false,
-				Message: "Slider deleted but failed to remove image",
-				Errors:  map[string]string{"image": err.Error()},
-			})
-			return
-		}
-	}
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "slider", slider.ID, slider, nil)
 
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete slider",
 	})
 }
+
+func sliderResponse(slider models.Slider) structs.SliderResponse {
+	return structs.SliderResponse{
+		ID:          slider.ID,
+		Image:       slider.Image,
+		Images:      helpers.EntityImages("sliders", slider.Image, slider.Media),
+		Media:       helpers.MediaSimpleResponse(slider.Media),
+		Description: slider.Description,
+		CreatedAt:   slider.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   slider.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// ReconcileUploads reports orphaned and missing upload files, orphans are
+// only touched when mode is delete or quarantine. An empty body is a dry
+// run.
+func ReconcileUploads(c *gin.Context) {
+	var req structs.ReconcileRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	report, err := helpers.ReconcileUploads(req.Mode)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to reconcile uploads",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if report.Mode != helpers.ReconcileModeDryRun {
+		helpers.RecordAudit(c, helpers.AuditActionReconcile, "storage", 0, nil, report)
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Uploads reconciled",
+		Data:    report,
+	})
+}
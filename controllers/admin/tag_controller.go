@@ -0,0 +1,217 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindTags(c *gin.Context) {
+	var tags []struct {
+		models.Tag
+		PostsCount int64
+	}
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Model(&models.Tag{})
+	if search != "" {
+		query = query.Where("name LIKE ?", "%"+search+"%")
+	}
+	query.Count(&total)
+
+	err := query.
+		Select("tags.id, tags.name, tags.slug, COUNT(post_tags.post_id) AS posts_count, tags.created_at, tags.updated_at").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Group("tags.id").
+		Order("tags.id DESC").Limit(limit).Offset(offset).
+		Scan(&tags).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	tagResponses := []structs.TagResponse{}
+	for _, tag := range tags {
+		tagResponses = append(tagResponses, structs.TagResponse{
+			ID:         tag.ID,
+			Name:       tag.Name,
+			Slug:       tag.Slug,
+			PostsCount: tag.PostsCount,
+			CreatedAt:  tag.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:  tag.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	helpers.PaginateResponse(c, tagResponses, total, page, limit, baseURL, search, "List Data Tags")
+}
+
+func CreateTag(c *gin.Context) {
+	var req structs.TagCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Error",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	tag := models.Tag{
+		Name: req.Name,
+		Slug: helpers.Slugify(req.Name),
+	}
+
+	if err := database.DB.Create(&tag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create tag",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "tag", tag.ID, nil, tag)
+
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Success create tag",
+		Data:    tag,
+	})
+}
+
+func FindTagByID(c *gin.Context) {
+	id := c.Param("id")
+	var tag models.Tag
+
+	if err := database.DB.First(&tag, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Tag not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Tag found",
+		Data:    tag,
+	})
+}
+
+func UpdateTag(c *gin.Context) {
+	id := c.Param("id")
+	var tag models.Tag
+
+	if err := database.DB.First(&tag, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Tag not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	before := tag
+
+	var req structs.TagUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Error",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	tag.Name = req.Name
+	tag.Slug = helpers.Slugify(req.Name)
+
+	if err := database.DB.Save(&tag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update tag",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "tag", tag.ID, before, tag)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success update tag",
+		Data:    tag,
+	})
+}
+
+func DeleteTag(c *gin.Context) {
+	id := c.Param("id")
+	var tag models.Tag
+
+	if err := database.DB.First(&tag, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Tag not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if err := database.DB.Exec("DELETE FROM post_tags WHERE tag_id = ?", tag.ID).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete tag",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if err := database.DB.Delete(&tag).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to delete tag",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "tag", tag.ID, tag, nil)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success delete tag",
+		Data:    nil,
+	})
+}
+
+func FindAllTags(c *gin.Context) {
+	var tags []models.Tag
+
+	if err := database.DB.Order("name ASC").Find(&tags).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Lists Data Tags",
+		Data:    tags,
+	})
+}
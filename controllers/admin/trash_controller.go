@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FindTrash lists the trashed items of one entity, e.g. "posts".
+func FindTrash(name string) gin.HandlerFunc {
+	entity := helpers.TrashEntities[name]
+
+	return func(c *gin.Context) {
+		var items []helpers.TrashedItem
+		var total int64
+
+		search, page, limit, offset := helpers.GetPaginationParams(c)
+		baseURL := helpers.BuildBaseURL(c)
+
+		query := entity.Trashed()
+		if search != "" {
+			query = query.Where(entity.TitleColumn+" LIKE ?", "%"+search+"%")
+		}
+		query.Count(&total)
+
+		err := query.Select(entity.ItemColumns()).Order("deleted_at DESC").Limit(limit).Offset(offset).Scan(&items).Error
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to fetch trash",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		retention := helpers.TrashRetention()
+		trashResponses := []structs.TrashResponse{}
+		for _, item := range items {
+			response := structs.TrashResponse{
+				ID:        item.ID,
+				Title:     item.Title,
+				Image:     item.Image,
+				DeletedAt: item.DeletedAt.Format("2006-01-02 15:04:05"),
+			}
+			if item.Image != "" {
+				response.Images = helpers.ImageURLs(entity.Directory, item.Image)
+			}
+			if retention > 0 {
+				response.PurgeAt = item.DeletedAt.Add(retention).Format("2006-01-02 15:04:05")
+			}
+			trashResponses = append(trashResponses, response)
+		}
+
+		helpers.PaginateResponse(c, trashResponses, total, page, limit, baseURL, search, "List Data Trash")
+	}
+}
+
+func RestoreTrash(name string) gin.HandlerFunc {
+	entity := helpers.TrashEntities[name]
+
+	return func(c *gin.Context) {
+		id, ok := trashID(c)
+		if !ok {
+			return
+		}
+
+		restored, err := entity.Restore(id)
+		if err != nil {
+			trashError(c, err, "Failed to restore item")
+			return
+		}
+
+		helpers.RecordAudit(c, helpers.AuditActionRestore, entity.Type, id, nil, restored)
+
+		c.JSON(http.StatusOK, structs.SuccessResponse{
+			Success: true,
+			Message: "Success restore item",
+			Data:    trashedResponse(c, restored),
+		})
+	}
+}
+
+// PurgeTrash deletes a trashed item and its files for good.
+func PurgeTrash(name string) gin.HandlerFunc {
+	entity := helpers.TrashEntities[name]
+
+	return func(c *gin.Context) {
+		id, ok := trashID(c)
+		if !ok {
+			return
+		}
+
+		purged, err := entity.Purge(id)
+		if err != nil {
+			trashError(c, err, "Failed to purge item")
+			return
+		}
+
+		helpers.RecordAudit(c, helpers.AuditActionPurge, entity.Type, id, purged, nil)
+
+		c.JSON(http.StatusOK, structs.SuccessResponse{
+			Success: true,
+			Message: "Success purge item",
+		})
+	}
+}
+
+func trashID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Item not found in trash",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func trashError(c *gin.Context, err error, message string) {
+	if helpers.IsDuplicateKey(err) {
+		c.JSON(http.StatusConflict, structs.ErrorResponse{
+			Success: false,
+			Message: message,
+			Errors:  map[string]string{"Slug": "Another item already uses this slug"},
+		})
+		return
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Item not found in trash",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+		Success: false,
+		Message: message,
+		Errors:  helpers.TranslateErrorMessage(err),
+	})
+}
+
+// trashedResponse reloads a restored row with its relations and returns
+// the same shape the entity's own show endpoint does.
+func trashedResponse(c *gin.Context, model any) any {
+	switch item := model.(type) {
+	case *models.Post:
+		database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").First(item, item.ID)
+		return postResponse(*item)
+	case *models.Page:
+		database.DB.Preload("Media").First(item, item.ID)
+		return pageResponse(*item)
+	case *models.Product:
+		database.DB.Preload("Media").First(item, item.ID)
+		return productResponse(*item)
+	case *models.Aparatur:
+		database.DB.Preload("Media").First(item, item.ID)
+		return aparaturResponse(*item)
+	case *models.Slider:
+		database.DB.Preload("Media").First(item, item.ID)
+		return sliderResponse(*item)
+	case *models.Album:
+		database.DB.Preload("Media").First(item, item.ID)
+		return helpers.AlbumResponses(c, []models.Album{*item})[0]
+	case *models.Photo:
+		return helpers.PhotoResponse(c, *item)
+	}
+	return model
+}
@@ -0,0 +1,324 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func authUser(c *gin.Context) (models.User, bool) {
+	var user models.User
+
+	username, ok := c.Get("username")
+	if !ok {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "User not authenticated",
+		})
+		return user, false
+	}
+
+	if err := database.DB.Preload("Roles.Permissions").Where("username = ?", username).First(&user).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "User not found",
+			Errors:  map[string]string{"user": "User data not found in database"},
+		})
+		return user, false
+	}
+
+	return user, true
+}
+
+func FindTwoFactor(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
+	var remaining int64
+	database.DB.Model(&models.RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", user.ID).Count(&remaining)
+
+	response := structs.TwoFactorStatusResponse{
+		Enabled:                user.TwoFactorEnabled,
+		Required:               helpers.RequiresTwoFactor(helpers.GetPermission(user.Roles)) && helpers.TwoFactorPolicyEnabled(),
+		RecoveryCodesRemaining: remaining,
+	}
+	if user.TwoFactorConfirmedAt != nil {
+		response.ConfirmedAt = user.TwoFactorConfirmedAt.Format("2006-01-02 15:04:05")
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Two-factor status",
+		Data:    response,
+	})
+}
+
+func EnableTwoFactor(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		c.JSON(http.StatusConflict, structs.ErrorResponse{
+			Success: false,
+			Message: "Two-factor authentication is already enabled",
+		})
+		return
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Something went wrong",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	err = database.DB.Model(&user).Updates(map[string]any{
+		"two_factor_secret":    secret,
+		"two_factor_last_step": 0,
+	}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to enable two-factor authentication",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	issuer := config.GetEnv("TWO_FACTOR_ISSUER", "Desa Digital")
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Scan the provisioning URI and confirm with a code",
+		Data: structs.TwoFactorSetupResponse{
+			Secret:          secret,
+			ProvisioningURI: helpers.TOTPProvisioningURI(issuer, user.Username, secret),
+		},
+	})
+}
+
+func ConfirmTwoFactor(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
+	var req structs.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		c.JSON(http.StatusConflict, structs.ErrorResponse{
+			Success: false,
+			Message: "Two-factor authentication is already enabled",
+		})
+		return
+	}
+
+	if !helpers.VerifyTwoFactorCode(&user, req.Code) {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid authentication code",
+			Errors:  map[string]string{"code": "The code is invalid"},
+		})
+		return
+	}
+
+	var codes []string
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&user).Updates(map[string]any{
+			"two_factor_enabled":      true,
+			"two_factor_confirmed_at": time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		codes, err = helpers.ReplaceRecoveryCodes(tx, user.ID)
+		return err
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to confirm two-factor authentication",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.InvalidatePermissions(user.Username)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Two-factor authentication enabled, store the recovery codes safely",
+		Data:    structs.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes},
+	})
+}
+
+func RegenerateRecoveryCodes(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
+	var req structs.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if !user.TwoFactorEnabled || !helpers.VerifyTwoFactorCode(&user, req.Code) {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid authentication code",
+			Errors:  map[string]string{"code": "The code is invalid"},
+		})
+		return
+	}
+
+	codes, err := helpers.ReplaceRecoveryCodes(database.DB, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to generate recovery codes",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Recovery codes regenerated",
+		Data:    structs.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes},
+	})
+}
+
+func DisableTwoFactor(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
+	var req structs.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid password",
+			Errors:  map[string]string{"Password": "Password is wrong"},
+		})
+		return
+	}
+
+	if !user.TwoFactorEnabled || !helpers.VerifyTwoFactorCode(&user, req.Code) {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid authentication code",
+			Errors:  map[string]string{"code": "The code is invalid"},
+		})
+		return
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&user).Updates(map[string]any{
+			"two_factor_enabled":      false,
+			"two_factor_secret":       "",
+			"two_factor_confirmed_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", user.ID).Delete(&models.RecoveryCode{}).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to disable two-factor authentication",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.InvalidatePermissions(user.Username)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Two-factor authentication disabled",
+		Data:    nil,
+	})
+}
+
+func FindTwoFactorPolicy(c *gin.Context) {
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Two-factor policy",
+		Data:    structs.TwoFactorPolicyResponse{Required: helpers.TwoFactorPolicyEnabled()},
+	})
+}
+
+func UpdateTwoFactorPolicy(c *gin.Context) {
+	var req structs.TwoFactorPolicyRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	before := structs.TwoFactorPolicyResponse{Required: helpers.TwoFactorPolicyEnabled()}
+
+	if err := helpers.SetSetting(helpers.SettingTwoFactorRequired, strconv.FormatBool(*req.Required)); err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to update two-factor policy",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.FlushPermissionCache()
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "setting", 0, before, structs.TwoFactorPolicyResponse{Required: *req.Required})
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success update two-factor policy",
+		Data:    structs.TwoFactorPolicyResponse{Required: *req.Required},
+	})
+}
@@ -0,0 +1,340 @@
+package admin
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// statusChecksumMismatch is the status tus uses when a chunk or the
+// finished file doesn't match its checksum.
+const statusChecksumMismatch = 460
+
+func CreateUpload(c *gin.Context) {
+	var req structs.UploadSessionCreateRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if !helpers.IsUploadExtensionAllowed(req.FileName) {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid file type",
+			Errors:  map[string]string{"FileName": "This file type can't be uploaded"},
+		})
+		return
+	}
+
+	if req.Size > helpers.UploadSessionMaxSize() {
+		c.JSON(http.StatusRequestEntityTooLarge, structs.ErrorResponse{
+			Success: false,
+			Message: "File size to large",
+			Errors:  map[string]string{"Size": "Maximum file is: " + strconv.FormatInt(helpers.UploadSessionMaxSize()/(1<<20), 10) + "MB"},
+		})
+		return
+	}
+
+	userID, ok := helpers.AuthUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "User not authenticated",
+		})
+		return
+	}
+
+	sessions, bytes, err := helpers.UploadSessionUsage(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create upload",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+	maxSessions, quota := helpers.UploadSessionUserLimits()
+	if sessions >= maxSessions || bytes+req.Size > quota {
+		c.JSON(http.StatusTooManyRequests, structs.ErrorResponse{
+			Success: false,
+			Message: "Too many open uploads",
+			Errors:  map[string]string{"Upload": "Finish or cancel an open upload first"},
+		})
+		return
+	}
+
+	session := models.UploadSession{
+		ID:          uuid.New().String(),
+		FileName:    req.FileName,
+		ContentType: helpers.UploadContentType(req.FileName),
+		Size:        req.Size,
+		Checksum:    strings.ToLower(req.Checksum),
+		Status:      models.UploadSessionStatusPending,
+		UserID:      userID,
+		ExpiresAt:   time.Now().Add(helpers.UploadSessionTTL()),
+	}
+
+	if err := helpers.WriteUploadChunk(session, 0, nil); err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create upload",
+			Errors:  map[string]string{"file": err.Error()},
+		})
+		return
+	}
+
+	if err := database.DB.Create(&session).Error; err != nil {
+		helpers.RemoveUploadSession(session)
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to create upload",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Header("Location", "/api/admin/uploads/"+session.ID)
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Success create upload",
+		Data:    helpers.UploadSessionResponse(session),
+	})
+}
+
+// FindUpload also answers HEAD so a client can ask where to resume.
+func FindUpload(c *gin.Context) {
+	session, ok := findOwnUploadSession(c)
+	if !ok {
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Upload found",
+		Data:    helpers.UploadSessionResponse(session),
+	})
+}
+
+// PatchUpload appends the request body at the Upload-Offset header. A chunk
+// sent for a stale offset is refused with the current one so the client can
+// resume from there.
+func PatchUpload(c *gin.Context) {
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"Upload-Offset": "Upload-Offset header is required"},
+		})
+		return
+	}
+
+	maxChunk := helpers.UploadChunkMaxSize()
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxChunk+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to read chunk",
+			Errors:  map[string]string{"file": err.Error()},
+		})
+		return
+	}
+	if int64(len(data)) > maxChunk {
+		c.JSON(http.StatusRequestEntityTooLarge, structs.ErrorResponse{
+			Success: false,
+			Message: "Chunk too large",
+			Errors:  map[string]string{"file": "Maximum chunk is: " + strconv.FormatInt(maxChunk/(1<<20), 10) + "MB"},
+		})
+		return
+	}
+
+	if header := c.GetHeader("Upload-Checksum"); header != "" {
+		algorithm, value, _ := strings.Cut(header, " ")
+		expected, err := base64.StdEncoding.DecodeString(value)
+		if !strings.EqualFold(algorithm, "sha256") || err != nil {
+			c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"Upload-Checksum": "Expected \"sha256 <base64 digest>\""},
+			})
+			return
+		}
+		if sum := sha256.Sum256(data); string(sum[:]) != string(expected) {
+			c.JSON(statusChecksumMismatch, structs.ErrorResponse{
+				Success: false,
+				Message: "Checksum mismatch",
+				Errors:  map[string]string{"Upload-Checksum": "The chunk was damaged in transit, send it again"},
+			})
+			return
+		}
+	}
+
+	userID, _ := helpers.AuthUserID(c)
+
+	var session models.UploadSession
+	var status int
+	var errResponse *structs.ErrorResponse
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// the row lock keeps two requests from writing the same session
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", c.Param("id"), userID).
+			First(&session).Error
+		if err != nil {
+			status, errResponse = http.StatusNotFound, &structs.ErrorResponse{
+				Success: false,
+				Message: "Upload not found",
+				Errors:  helpers.TranslateErrorMessage(err),
+			}
+			return err
+		}
+
+		if session.Status != models.UploadSessionStatusPending {
+			status, errResponse = http.StatusConflict, &structs.ErrorResponse{
+				Success: false,
+				Message: "Upload already completed",
+			}
+			return errors.New("upload completed")
+		}
+
+		if offset != session.Offset {
+			status, errResponse = http.StatusConflict, &structs.ErrorResponse{
+				Success: false,
+				Message: "Offset mismatch",
+				Errors:  map[string]string{"Upload-Offset": "Upload must resume at offset " + strconv.FormatInt(session.Offset, 10)},
+			}
+			return errors.New("offset mismatch")
+		}
+
+		if offset+int64(len(data)) > session.Size {
+			status, errResponse = http.StatusRequestEntityTooLarge, &structs.ErrorResponse{
+				Success: false,
+				Message: "Chunk exceeds the upload size",
+			}
+			return errors.New("upload too large")
+		}
+
+		if err := helpers.WriteUploadChunk(session, offset, data); err != nil {
+			status, errResponse = http.StatusInternalServerError, &structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to write chunk",
+				Errors:  map[string]string{"file": err.Error()},
+			}
+			return err
+		}
+
+		session.Offset = offset + int64(len(data))
+		session.ExpiresAt = time.Now().Add(helpers.UploadSessionTTL())
+
+		if session.Offset == session.Size {
+			if err := helpers.VerifyUploadChecksum(session); err != nil {
+				// the assembled file is unusable, start over
+				session.Offset = 0
+				helpers.WriteUploadChunk(session, 0, nil)
+				tx.Save(&session)
+
+				status, errResponse = statusChecksumMismatch, &structs.ErrorResponse{
+					Success: false,
+					Message: "Checksum mismatch",
+					Errors:  map[string]string{"checksum": err.Error()},
+				}
+				return nil
+			}
+
+			now := time.Now()
+			session.Status = models.UploadSessionStatusCompleted
+			session.CompletedAt = &now
+		}
+
+		return tx.Save(&session).Error
+	})
+	if errResponse == nil && err != nil {
+		status, errResponse = http.StatusInternalServerError, &structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to write chunk",
+			Errors:  helpers.TranslateErrorMessage(err),
+		}
+	}
+	if errResponse != nil {
+		if session.ID != "" {
+			setUploadHeaders(c, session)
+		}
+		c.JSON(status, errResponse)
+		return
+	}
+
+	setUploadHeaders(c, session)
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success upload chunk",
+		Data:    helpers.UploadSessionResponse(session),
+	})
+}
+
+func DeleteUpload(c *gin.Context) {
+	session, ok := findOwnUploadSession(c)
+	if !ok {
+		return
+	}
+
+	// a create or update handler is storing it right now
+	if session.Status == models.UploadSessionStatusClaimed {
+		c.JSON(http.StatusConflict, structs.ErrorResponse{
+			Success: false,
+			Message: "Upload is being used",
+		})
+		return
+	}
+
+	helpers.RemoveUploadSession(session)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success delete upload",
+	})
+}
+
+// findOwnUploadSession loads the upload in the route, uploads of other
+// users are reported as missing.
+func findOwnUploadSession(c *gin.Context) (models.UploadSession, bool) {
+	var session models.UploadSession
+	userID, _ := helpers.AuthUserID(c)
+
+	err := database.DB.Where("id = ? AND user_id = ?", c.Param("id"), userID).First(&session).Error
+	if err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Upload not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return session, false
+	}
+	return session, true
+}
+
+func setUploadHeaders(c *gin.Context, session models.UploadSession) {
+	c.Header("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(session.Size, 10))
+}
@@ -2,6 +2,7 @@ package admin
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/helpers"
@@ -94,6 +95,8 @@ func CreateUser(c *gin.Context) {
 		return
 	}
 
+	helpers.RecordAudit(c, helpers.AuditActionCreate, "user", user.ID, nil, user)
+
 	c.JSON(http.StatusCreated, structs.SuccessResponse{
 		Success: true,
 		Message: "Success create user",
@@ -145,7 +148,7 @@ func UpdateUser(c *gin.Context) {
 	id := c.Param("id")
 	var user models.User
 
-	if err := database.DB.Where("id = ?", id).First(&user).Error; err != nil {
+	if err := database.DB.Preload("Roles").Where("id = ?", id).First(&user).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "User not found",
@@ -154,6 +157,8 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 
+	before := user
+
 	var req structs.UserUpdateRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -195,6 +200,9 @@ func UpdateUser(c *gin.Context) {
 		return
 	}
 
+	helpers.InvalidatePermissions(before.Username, user.Username)
+	helpers.RecordAudit(c, helpers.AuditActionUpdate, "user", user.ID, before, user)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success update user",
@@ -233,9 +241,48 @@ func DeleteUser(c *gin.Context) {
 		return
 	}
 
+	helpers.InvalidatePermissions(user.Username)
+	helpers.RecordAudit(c, helpers.AuditActionDelete, "user", user.ID, user, nil)
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Success delete user",
 		Data:    nil,
 	})
 }
+
+func UnlockUser(c *gin.Context) {
+	id := c.Param("id")
+	var user models.User
+
+	if err := database.DB.First(&user, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "User not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	username := strings.ToLower(user.Username)
+	err := database.DB.
+		Where("scope = ? AND identifier = ?", "username", username).
+		Or("scope = ? AND username = ?", "ip", username).
+		Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to unlock user",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.RecordAudit(c, helpers.AuditActionUnlock, "user", user.ID, nil, nil)
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Success unlock user",
+		Data:    nil,
+	})
+}
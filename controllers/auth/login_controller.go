@@ -25,27 +25,56 @@ func Login(c *gin.Context) {
 		return
 	}
 
+	if lockedUntil, locked := loginLockedUntil(req.Username, c.ClientIP()); locked {
+		abortLocked(c, lockedUntil)
+		return
+	}
+
 	err = database.DB.Preload("Roles").Preload("Roles.Permissions").Where("username = ?", req.Username).First(&user).Error
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
-			Success: false,
-			Message: "User Not Found",
-			Errors:  helpers.TranslateErrorMessage(err),
-		})
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(req.Password))
+		registerLoginFailure(req.Username, c.ClientIP())
+		abortInvalidCredentials(c)
 		return
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
-			Success: false,
-			Message: "Username or Password is Wrong",
-			Errors:  helpers.TranslateErrorMessage(err),
+		registerLoginFailure(req.Username, c.ClientIP())
+		abortInvalidCredentials(c)
+		return
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, expiresAt, err := helpers.GenerateTwoFactorChallenge(user.Username)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+				Success: false,
+				Message: "Someting went wrong",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, structs.SuccessResponse{
+			Success: true,
+			Message: "Two-factor authentication required",
+			Data: structs.TwoFactorChallengeResponse{
+				TwoFactorRequired: true,
+				ChallengeToken:    challenge,
+				ExpiresAt:         expiresAt.Format("2006-01-02 15:04:05"),
+			},
 		})
 		return
 	}
 
-	token, err := helpers.GenerateToken(user.Username)
+	loginSuccess(c, user)
+}
+
+func loginSuccess(c *gin.Context, user models.User) {
+	clearLoginFailures(user.Username)
+
+	tokens, err := createSession(c, user)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -61,12 +90,14 @@ func Login(c *gin.Context) {
 		Success: true,
 		Message: "Login Success",
 		Data: structs.UserResponse{
-			ID:          user.ID,
-			Name:        user.Name,
-			Username:    user.Username,
-			Email:       user.Email,
-			Permissions: permissionMap,
-			Token:       &token,
+			ID:                     user.ID,
+			Name:                   user.Name,
+			Username:               user.Username,
+			Email:                  user.Email,
+			Permissions:            permissionMap,
+			Token:                  &tokens.Token,
+			Tokens:                 &tokens,
+			TwoFactorSetupRequired: !user.TwoFactorEnabled && helpers.RequiresTwoFactor(permissionMap) && helpers.TwoFactorPolicyEnabled(),
 		},
 	})
 }
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	throttleScopeUsername = "username"
+	throttleScopeIP       = "ip"
+
+	maxUsernameFailures = 5
+	maxIPFailures       = 20
+	failureWindow       = 15 * time.Minute
+	baseLockout         = 30 * time.Second
+	maxLockout          = time.Hour
+)
+
+// dummyHash is compared against when the username doesn't exist so both
+// branches cost one bcrypt round and response timing doesn't leak it.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("desa-digital"), bcrypt.DefaultCost)
+
+func throttleKeys(username, ip string) map[string]string {
+	return map[string]string{
+		throttleScopeUsername: strings.ToLower(strings.TrimSpace(username)),
+		throttleScopeIP:       ip,
+	}
+}
+
+// loginLockedUntil returns the latest lockout that applies to either the
+// username or the client IP.
+func loginLockedUntil(username, ip string) (time.Time, bool) {
+	var lockedUntil time.Time
+
+	for scope, identifier := range throttleKeys(username, ip) {
+		var attempt models.LoginAttempt
+		err := database.DB.Where("scope = ? AND identifier = ?", scope, identifier).First(&attempt).Error
+		if err != nil || attempt.LockedUntil == nil {
+			continue
+		}
+		if attempt.LockedUntil.After(lockedUntil) {
+			lockedUntil = *attempt.LockedUntil
+		}
+	}
+
+	return lockedUntil, lockedUntil.After(time.Now())
+}
+
+func registerLoginFailure(username, ip string) {
+	now := time.Now()
+
+	for scope, identifier := range throttleKeys(username, ip) {
+		var attempt models.LoginAttempt
+		database.DB.Where(models.LoginAttempt{Scope: scope, Identifier: identifier}).FirstOrCreate(&attempt)
+
+		// forget old failures once the window has passed without a lockout
+		if now.Sub(attempt.LastFailedAt) > failureWindow && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(now)) {
+			attempt.Failures = 0
+		}
+
+		// an IP row keeps the username its run of failures started with, so
+		// unlocking that user clears it and later guesses can't move it
+		if scope == throttleScopeIP && attempt.Failures == 0 {
+			attempt.Username = strings.ToLower(strings.TrimSpace(username))
+		}
+
+		attempt.Failures++
+		attempt.LastFailedAt = now
+
+		threshold := maxUsernameFailures
+		if scope == throttleScopeIP {
+			threshold = maxIPFailures
+		}
+
+		if attempt.Failures >= threshold {
+			lockout := time.Duration(float64(baseLockout) * math.Pow(2, float64(attempt.Failures-threshold)))
+			if lockout > maxLockout || lockout <= 0 {
+				lockout = maxLockout
+			}
+			lockedUntil := now.Add(lockout)
+			attempt.LockedUntil = &lockedUntil
+		}
+
+		database.DB.Save(&attempt)
+	}
+}
+
+func clearLoginFailures(username string) {
+	database.DB.
+		Where("scope = ? AND identifier = ?", throttleScopeUsername, strings.ToLower(strings.TrimSpace(username))).
+		Delete(&models.LoginAttempt{})
+}
+
+func abortLocked(c *gin.Context, lockedUntil time.Time) {
+	retryAfter := int(math.Ceil(time.Until(lockedUntil).Seconds()))
+
+	c.Header("Retry-After", fmt.Sprint(retryAfter))
+	c.JSON(http.StatusTooManyRequests, structs.ErrorResponse{
+		Success: false,
+		Message: "Too many login attempts",
+		Errors:  map[string]string{"Error": fmt.Sprintf("Please try again in %d seconds", retryAfter)},
+	})
+}
+
+func abortInvalidCredentials(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+		Success: false,
+		Message: "Username or Password is Wrong",
+		Errors:  map[string]string{"Error": "Username or password is wrong"},
+	})
+}
@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var errResetTokenUsed = errors.New("reset token already used")
+
+func passwordResetTTL() time.Duration {
+	minutes, err := strconv.Atoi(config.GetEnv("PASSWORD_RESET_TTL_MINUTES", "60"))
+	if err != nil || minutes < 1 {
+		minutes = 60
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func ForgotPassword(c *gin.Context) {
+	var req structs.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	// The response is the same whether or not the email exists so the
+	// endpoint can't be used to enumerate accounts.
+	response := structs.SuccessResponse{
+		Success: true,
+		Message: "If the email is registered, a password reset link has been sent",
+		Data:    nil,
+	}
+
+	var user models.User
+	if err := database.DB.Where("email = ?", req.Email).First(&user).Error; err != nil {
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	token, tokenHash, err := helpers.GenerateOpaqueToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Someting went wrong",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	reset := models.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(passwordResetTTL()),
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// only the most recent link stays usable
+		if err := tx.Where("user_id = ? AND used_at IS NULL", user.ID).Delete(&models.PasswordReset{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&reset).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Someting went wrong",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	resetURL := fmt.Sprintf("%s?token=%s", config.GetEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"), url.QueryEscape(token))
+
+	message := helpers.MailMessage{
+		To:      user.Email,
+		Subject: "Reset Password",
+		Body: fmt.Sprintf(
+			"Halo %s,\n\nKami menerima permintaan untuk mengatur ulang password akun Anda.\nBuka tautan berikut untuk membuat password baru:\n\n%s\n\nTautan ini berlaku selama %d menit dan hanya dapat digunakan satu kali.\nAbaikan email ini jika Anda tidak merasa memintanya.\n",
+			user.Name, resetURL, int(passwordResetTTL().Minutes()),
+		),
+	}
+
+	// sent in the background so a registered email doesn't answer slower
+	// than an unknown one
+	go func() {
+		if err := helpers.NewMailer().Send(message); err != nil {
+			log.Println("failed to send password reset email:", err)
+		}
+	}()
+
+	c.JSON(http.StatusOK, response)
+}
+
+func ResetPassword(c *gin.Context) {
+	var req structs.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var reset models.PasswordReset
+	err := database.DB.
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", helpers.HashToken(req.Token), time.Now()).
+		First(&reset).Error
+	if err != nil {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid or expired reset token",
+			Errors:  map[string]string{"token": "Reset token is invalid or has expired"},
+		})
+		return
+	}
+
+	hashPass, err := helpers.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Someting went wrong",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.PasswordReset{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errResetTokenUsed
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", reset.UserID).Update("password", hashPass).Error; err != nil {
+			return err
+		}
+
+		// sign the user out everywhere, the old password may have been compromised
+		return tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", reset.UserID).
+			Update("revoked_at", time.Now()).Error
+	})
+
+	if errors.Is(err, errResetTokenUsed) {
+		c.JSON(http.StatusBadRequest, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid or expired reset token",
+			Errors:  map[string]string{"token": "Reset token is invalid or has expired"},
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to reset password",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Password has been reset",
+		Data:    nil,
+	})
+}
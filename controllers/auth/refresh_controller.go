@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var errRefreshTokenReused = errors.New("refresh token reused")
+
+func Refresh(c *gin.Context) {
+	var req structs.RefreshTokenRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var refresh models.RefreshToken
+	err := database.DB.Preload("Session.User").
+		Where("token_hash = ?", helpers.HashToken(req.RefreshToken)).
+		First(&refresh).Error
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid refresh token",
+			Errors:  map[string]string{"refresh_token": "Refresh token is invalid"},
+		})
+		return
+	}
+
+	session := refresh.Session
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) || time.Now().After(refresh.ExpiresAt) {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Session expired",
+			Errors:  map[string]string{"refresh_token": "Refresh token is expired or revoked"},
+		})
+		return
+	}
+
+	var tokens structs.TokenResponse
+	err = database.DB.Transaction(func(tx *gorm.DB) error {
+		// Mark the token as used only if nobody else did it first, a second
+		// presentation of the same token means it has leaked.
+		result := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", refresh.ID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errRefreshTokenReused
+		}
+
+		var err error
+		tokens, err = issueTokens(tx, session.User, session)
+		return err
+	})
+
+	if errors.Is(err, errRefreshTokenReused) {
+		if err := revokeSession(database.DB, session.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+				Success: false,
+				Message: "Someting went wrong",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Refresh token reuse detected",
+			Errors:  map[string]string{"refresh_token": "Session has been revoked, please login again"},
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Someting went wrong",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Token refreshed",
+		Data:    tokens,
+	})
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func createSession(c *gin.Context, user models.User) (structs.TokenResponse, error) {
+	session := models.Session{
+		UUID:      uuid.New().String(),
+		UserID:    user.ID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		ExpiresAt: time.Now().Add(helpers.RefreshTokenTTL()),
+	}
+
+	var tokens structs.TokenResponse
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&session).Error; err != nil {
+			return err
+		}
+
+		var err error
+		tokens, err = issueTokens(tx, user, session)
+		return err
+	})
+
+	return tokens, err
+}
+
+// issueTokens signs a new access token for the session and stores a fresh
+// refresh token belonging to it.
+func issueTokens(tx *gorm.DB, user models.User, session models.Session) (structs.TokenResponse, error) {
+	accessToken, accessExpiresAt, err := helpers.GenerateAccessToken(user.Username, session.UUID)
+	if err != nil {
+		return structs.TokenResponse{}, err
+	}
+
+	refreshToken, refreshHash, err := helpers.GenerateRefreshToken()
+	if err != nil {
+		return structs.TokenResponse{}, err
+	}
+
+	refresh := models.RefreshToken{
+		SessionID: session.ID,
+		TokenHash: refreshHash,
+		ExpiresAt: session.ExpiresAt,
+	}
+	if err := tx.Create(&refresh).Error; err != nil {
+		return structs.TokenResponse{}, err
+	}
+
+	return structs.TokenResponse{
+		Token:            accessToken,
+		RefreshToken:     refreshToken,
+		TokenType:        "Bearer",
+		ExpiresAt:        accessExpiresAt.Format("2006-01-02 15:04:05"),
+		RefreshExpiresAt: refresh.ExpiresAt.Format("2006-01-02 15:04:05"),
+	}, nil
+}
+
+func revokeSession(tx *gorm.DB, sessionID uint) error {
+	return tx.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", sessionID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func LoginTwoFactor(c *gin.Context) {
+	var req structs.TwoFactorLoginRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"Code": "Code or RecoveryCode is required"},
+		})
+		return
+	}
+
+	username, err := helpers.ParseTwoFactorChallenge(req.ChallengeToken)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid or expired challenge",
+			Errors:  map[string]string{"challenge_token": "Please login again"},
+		})
+		return
+	}
+
+	if lockedUntil, locked := loginLockedUntil(username, c.ClientIP()); locked {
+		abortLocked(c, lockedUntil)
+		return
+	}
+
+	var user models.User
+	err = database.DB.Preload("Roles").Preload("Roles.Permissions").Where("username = ?", username).First(&user).Error
+	if err != nil || !user.TwoFactorEnabled {
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid or expired challenge",
+			Errors:  map[string]string{"challenge_token": "Please login again"},
+		})
+		return
+	}
+
+	verified := false
+	if req.Code != "" {
+		verified = helpers.VerifyTwoFactorCode(&user, req.Code)
+	} else {
+		verified = helpers.UseRecoveryCode(user.ID, req.RecoveryCode)
+	}
+
+	if !verified {
+		registerLoginFailure(username, c.ClientIP())
+		c.JSON(http.StatusUnauthorized, structs.ErrorResponse{
+			Success: false,
+			Message: "Invalid authentication code",
+			Errors:  map[string]string{"code": "The code is invalid or has already been used"},
+		})
+		return
+	}
+
+	loginSuccess(c, user)
+}
@@ -0,0 +1,84 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindAlbums(c *gin.Context) {
+	var albums []models.Album
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Preload("Media").Model(&models.Album{})
+	if search != "" {
+		query = query.Where("title LIKE ?", "%"+search+"%")
+	}
+	query.Count(&total)
+
+	err := query.Order("sort_order ASC, event_date DESC NULLS LAST, id DESC").Limit(limit).Offset(offset).Find(&albums).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch albums",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.PaginateResponse(c, helpers.AlbumResponses(c, albums), total, page, limit, baseURL, search, "List Data Albums")
+}
+
+// FindAlbumBySlug returns the album with one page of its photos, paginated
+// with the usual page and limit parameters.
+func FindAlbumBySlug(c *gin.Context) {
+	slug := c.Param("slug")
+	var album models.Album
+
+	if err := database.DB.Preload("Media").First(&album, "slug = ?", slug).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Album not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var photos []models.Photo
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Model(&models.Photo{}).Where("album_id = ?", album.ID)
+	if search != "" {
+		query = query.Where("caption LIKE ?", "%"+search+"%")
+	}
+	query.Count(&total)
+
+	err := query.Order("sort_order ASC, id ASC").Limit(limit).Offset(offset).Find(&photos).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch photos",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "Album found",
+		Data: structs.AlbumWithPhotosResponse{
+			AlbumResponse: helpers.AlbumResponses(c, []models.Album{album})[0],
+			Photos:        helpers.PaginationData(photoResponses(c, photos), total, page, limit, baseURL, search),
+		},
+	})
+}
@@ -17,7 +17,7 @@ func FindAparaturs(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Model(&models.Aparatur{})
+	query := database.DB.Preload("Media").Model(&models.Aparatur{})
 	if search != "" {
 		query = query.Where("name LIKE ? OR position LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -33,14 +33,29 @@ func FindAparaturs(c *gin.Context) {
 		return
 	}
 
-	helpers.PaginateResponse(c, aparaturs, total, page, limit, baseURL, search, "List Data Aparaturs")
+	aparaturResponses := []structs.AparaturResponse{}
+	for _, aparatur := range aparaturs {
+		aparaturResponses = append(aparaturResponses, structs.AparaturResponse{
+			ID:          aparatur.ID,
+			Image:       aparatur.Image,
+			Images:      helpers.EntityImages("aparaturs", aparatur.Image, aparatur.Media),
+			Media:       helpers.MediaSimpleResponse(aparatur.Media),
+			Name:        aparatur.Name,
+			Position:    aparatur.Position,
+			Description: aparatur.Description,
+			CreatedAt:   aparatur.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:   aparatur.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	helpers.PaginateResponse(c, aparaturResponses, total, page, limit, baseURL, search, "List Data Aparaturs")
 }
 
 func FindAparaturByID(c *gin.Context) {
 	id := c.Param("id")
 	var aparatur models.Aparatur
 
-	if err := database.DB.First(&aparatur, "id = ?", id).Error; err != nil {
+	if err := database.DB.Preload("Media").First(&aparatur, "id = ?", id).Error; err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
 			Message: "Aparatur not found",
@@ -55,6 +70,8 @@ func FindAparaturByID(c *gin.Context) {
 		Data: structs.AparaturResponse{
 			ID:          aparatur.ID,
 			Image:       aparatur.Image,
+			Images:      helpers.EntityImages("aparaturs", aparatur.Image, aparatur.Media),
+			Media:       helpers.MediaSimpleResponse(aparatur.Media),
 			Name:        aparatur.Name,
 			Position:    aparatur.Position,
 			Description: aparatur.Description,
@@ -67,7 +84,7 @@ func FindAparaturByID(c *gin.Context) {
 func FindAparatursHome(c *gin.Context) {
 	var aparaturs []models.Aparatur
 
-	err := database.DB.Order("id DESC").Limit(6).Find(&aparaturs).Error
+	err := database.DB.Preload("Media").Order("id DESC").Limit(6).Find(&aparaturs).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -0,0 +1,99 @@
+package public
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func CreateComment(c *gin.Context) {
+	slug := c.Param("slug")
+	var post models.Post
+
+	if err := database.DB.Scopes(helpers.PublishedPosts).First(&post, "slug = ?", slug).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Post not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var req structs.CommentCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	// bots filling the honeypot get the normal answer but nothing is stored
+	if req.Website != "" {
+		c.JSON(http.StatusCreated, structs.SuccessResponse{
+			Success: true,
+			Message: "Comment submitted and awaiting moderation",
+			Data:    nil,
+		})
+		return
+	}
+
+	if req.ParentID != nil {
+		var parent models.Comment
+		err := database.DB.
+			Where("id = ? AND post_id = ? AND status = ?", *req.ParentID, post.ID, models.CommentStatusApproved).
+			First(&parent).Error
+		if err != nil {
+			c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+				Success: false,
+				Message: "Validation Errors",
+				Errors:  map[string]string{"ParentID": "Parent comment not found"},
+			})
+			return
+		}
+	}
+
+	// visitors are anonymous, their name and comment are kept as plain text
+	comment := models.Comment{
+		PostID:    post.ID,
+		ParentID:  req.ParentID,
+		Name:      strings.TrimSpace(helpers.SanitizeHTML(req.Name, helpers.SanitizePolicyStrict)),
+		Email:     req.Email,
+		Content:   strings.TrimSpace(helpers.SanitizeHTML(req.Content, helpers.SanitizePolicyStrict)),
+		Status:    models.CommentStatusPending,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+	if comment.Name == "" || comment.Content == "" {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"Content": "Name and content must contain text"},
+		})
+		return
+	}
+	if helpers.IsLikelySpamComment(comment.Content) {
+		comment.Status = models.CommentStatusSpam
+	}
+
+	if err := database.DB.Create(&comment).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to submit comment",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, structs.SuccessResponse{
+		Success: true,
+		Message: "Comment submitted and awaiting moderation",
+		Data:    nil,
+	})
+}
@@ -0,0 +1,88 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+const feedLimit = 20
+
+func PostsRSS(c *gin.Context) {
+	writePostsFeed(c, "rss", "")
+}
+
+func PostsAtom(c *gin.Context) {
+	writePostsFeed(c, "atom", "")
+}
+
+func CategoryPostsRSS(c *gin.Context) {
+	writePostsFeed(c, "rss", c.Param("slug"))
+}
+
+func CategoryPostsAtom(c *gin.Context) {
+	writePostsFeed(c, "atom", c.Param("slug"))
+}
+
+func writePostsFeed(c *gin.Context, format, categorySlug string) {
+	siteURL := helpers.SiteURL()
+	channel := helpers.FeedChannel{
+		Title:       config.GetEnv("APP_NAME", "Desa Digital"),
+		Description: "Berita terbaru " + config.GetEnv("APP_NAME", "Desa Digital"),
+		SiteURL:     siteURL,
+		SelfURL:     siteURL + "/feed/posts",
+	}
+
+	query := database.DB.Preload("Category").Preload("User").Preload("Media").Scopes(helpers.PublishedPosts)
+
+	if categorySlug != "" {
+		var category models.Category
+		if err := database.DB.First(&category, "slug = ?", categorySlug).Error; err != nil {
+			c.JSON(http.StatusNotFound, structs.ErrorResponse{
+				Success: false,
+				Message: "Category not found",
+				Errors:  helpers.TranslateErrorMessage(err),
+			})
+			return
+		}
+
+		channel.Title += " - " + category.Name
+		channel.Description = "Berita " + category.Name
+		channel.SelfURL = siteURL + "/feed/categories/" + category.Slug + "/posts"
+		query = query.Where("category_id = ?", category.ID)
+	}
+
+	if err := query.Order("published_at DESC, id DESC").Limit(feedLimit).Find(&channel.Posts).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch posts",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var body []byte
+	var err error
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == "atom" {
+		body, err = helpers.BuildAtom(channel)
+		contentType = "application/atom+xml; charset=utf-8"
+	} else {
+		body, err = helpers.BuildRSS(channel)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to build feed",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.WriteCacheable(c, contentType, body, helpers.FeedLastModified(channel.Posts))
+}
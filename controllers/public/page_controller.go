@@ -17,7 +17,7 @@ func FindPages(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("User").Model(&models.Page{})
+	query := database.DB.Preload("User").Preload("Media").Model(&models.Page{})
 	if search != "" {
 		query = query.Where("title LIKE ? OR content LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -36,9 +36,11 @@ func FindPages(c *gin.Context) {
 	pageResponses := []structs.PageWithRelationResponse{}
 	for _, page := range pages {
 		pageResponses = append(pageResponses, structs.PageWithRelationResponse{
-			ID:    page.ID,
-			Title: page.Title,
-			Slug:  page.Slug,
+			ID:     page.ID,
+			Title:  page.Title,
+			Slug:   page.Slug,
+			Images: helpers.EntityImages("", "", page.Media),
+			Media:  helpers.MediaSimpleResponse(page.Media),
 			User: structs.UserSimpleResponse{
 				ID:   page.User.ID,
 				Name: page.User.Name,
@@ -55,7 +57,7 @@ func FindPageBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	var page models.Page
 
-	err := database.DB.Preload("User").First(&page, "slug = ?", slug).Error
+	err := database.DB.Preload("User").Preload("Media").First(&page, "slug = ?", slug).Error
 	if err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
@@ -65,18 +67,25 @@ func FindPageBySlug(c *gin.Context) {
 		return
 	}
 
+	siteURL := helpers.SiteURL()
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Page found",
 		Data: structs.PageWithRelationResponse{
-			ID:      page.ID,
-			Title:   page.Title,
-			Slug:    page.Slug,
-			Content: page.Content,
+			ID:            page.ID,
+			Title:         page.Title,
+			Slug:          page.Slug,
+			Content:       page.Content,
+			ContentFormat: page.ContentFormat,
+			ContentHTML:   page.ContentHTML,
+			Images:        helpers.EntityImages("", "", page.Media),
+			Media:         helpers.MediaSimpleResponse(page.Media),
 			User: structs.UserSimpleResponse{
 				ID:   page.User.ID,
 				Name: page.User.Name,
 			},
+			SEO:       helpers.ResolveSEO(page.SEO, page.Title, page.ContentHTML, siteURL+"/pages/"+page.Slug, helpers.EntityImageURL("", "", page.Media), "website"),
 			CreatedAt: page.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: page.UpdatedAt.Format("2006-01-02 15:04:05"),
 		},
@@ -21,6 +21,9 @@ func FindPhotos(c *gin.Context) {
 	if search != "" {
 		query = query.Where("caption LIKE ?", "%"+search+"%")
 	}
+	if albumID := c.Query("album_id"); albumID != "" {
+		query = query.Where("album_id = ?", albumID)
+	}
 	query.Count(&total)
 
 	err := query.Order("id desc").Limit(limit).Offset(offset).Find(&photos).Error
@@ -33,7 +36,7 @@ func FindPhotos(c *gin.Context) {
 		return
 	}
 
-	helpers.PaginateResponse(c, photos, total, page, limit, baseURL, search, "List Data Photos")
+	helpers.PaginateResponse(c, photoResponses(c, photos), total, page, limit, baseURL, search, "List Data Photos")
 }
 
 func FindPhotosHome(c *gin.Context) {
@@ -52,6 +55,14 @@ func FindPhotosHome(c *gin.Context) {
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "List Data Photos Home",
-		Data:    photos,
+		Data:    photoResponses(c, photos),
 	})
 }
+
+func photoResponses(c *gin.Context, photos []models.Photo) []structs.PhotoResponse {
+	responses := []structs.PhotoResponse{}
+	for _, photo := range photos {
+		responses = append(responses, helpers.PhotoResponse(c, photo))
+	}
+	return responses
+}
@@ -17,13 +17,13 @@ func FindPosts(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("Category").Preload("User").Model(&models.Post{})
+	query := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").Model(&models.Post{}).Scopes(helpers.PublishedPosts)
 	if search != "" {
 		query = query.Where("title LIKE ?", "%"+search+"%")
 	}
 	query.Count(&total)
 
-	err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&posts).Error
+	err := query.Order("published_at DESC, id DESC").Limit(limit).Offset(offset).Find(&posts).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -36,11 +36,16 @@ func FindPosts(c *gin.Context) {
 	postResponses := []structs.PostWithRelationResponse{}
 	for _, post := range posts {
 		postResponses = append(postResponses, structs.PostWithRelationResponse{
-			ID:      post.ID,
-			Image:   post.Image,
-			Title:   post.Title,
-			Slug:    post.Slug,
-			Content: post.Content,
+			ID:            post.ID,
+			Image:         post.Image,
+			Images:        helpers.EntityImages("posts", post.Image, post.Media),
+			Media:         helpers.MediaSimpleResponse(post.Media),
+			Title:         post.Title,
+			Slug:          post.Slug,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			ContentHTML:   post.ContentHTML,
+			PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
 			Category: structs.CategorySimpleResponse{
 				ID:   post.Category.ID,
 				Name: post.Category.Name,
@@ -49,6 +54,7 @@ func FindPosts(c *gin.Context) {
 				ID:   post.User.ID,
 				Name: post.User.Name,
 			},
+			Tags:      helpers.TagSimpleResponses(post.Tags),
 			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -61,7 +67,7 @@ func FindPostBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	var post models.Post
 
-	err := database.DB.Preload("Category").Preload("User").First(&post, "slug = ?", slug).Error
+	err := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").Scopes(helpers.PublishedPosts).First(&post, "slug = ?", slug).Error
 	if err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
@@ -71,15 +77,25 @@ func FindPostBySlug(c *gin.Context) {
 		return
 	}
 
+	var comments []models.Comment
+	database.DB.Where("post_id = ? AND status = ?", post.ID, models.CommentStatusApproved).Order("id ASC").Find(&comments)
+
+	siteURL := helpers.SiteURL()
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Post found",
 		Data: structs.PostWithRelationResponse{
-			ID:      post.ID,
-			Image:   post.Image,
-			Title:   post.Title,
-			Slug:    post.Slug,
-			Content: post.Content,
+			ID:            post.ID,
+			Image:         post.Image,
+			Images:        helpers.EntityImages("posts", post.Image, post.Media),
+			Media:         helpers.MediaSimpleResponse(post.Media),
+			Title:         post.Title,
+			Slug:          post.Slug,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			ContentHTML:   post.ContentHTML,
+			PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
 			Category: structs.CategorySimpleResponse{
 				ID:   post.Category.ID,
 				Name: post.Category.Name,
@@ -88,6 +104,9 @@ func FindPostBySlug(c *gin.Context) {
 				ID:   post.User.ID,
 				Name: post.User.Name,
 			},
+			Tags:      helpers.TagSimpleResponses(post.Tags),
+			Comments:  helpers.CommentTree(comments),
+			SEO:       helpers.ResolveSEO(post.SEO, post.Title, post.ContentHTML, helpers.PostURL(siteURL, post), helpers.EntityImageURL("posts", post.Image, post.Media), "article"),
 			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
 		},
@@ -97,7 +116,7 @@ func FindPostBySlug(c *gin.Context) {
 func FindPostsHome(c *gin.Context) {
 	var posts []models.Post
 
-	err := database.DB.Preload("Category").Preload("User").Order("id DESC").Limit(6).Find(&posts).Error
+	err := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").Scopes(helpers.PublishedPosts).Order("published_at DESC, id DESC").Limit(6).Find(&posts).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -110,11 +129,16 @@ func FindPostsHome(c *gin.Context) {
 	postResponses := []structs.PostWithRelationResponse{}
 	for _, post := range posts {
 		postResponses = append(postResponses, structs.PostWithRelationResponse{
-			ID:      post.ID,
-			Image:   post.Image,
-			Title:   post.Title,
-			Slug:    post.Slug,
-			Content: post.Content,
+			ID:            post.ID,
+			Image:         post.Image,
+			Images:        helpers.EntityImages("posts", post.Image, post.Media),
+			Media:         helpers.MediaSimpleResponse(post.Media),
+			Title:         post.Title,
+			Slug:          post.Slug,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			ContentHTML:   post.ContentHTML,
+			PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
 			Category: structs.CategorySimpleResponse{
 				ID:   post.Category.ID,
 				Name: post.Category.Name,
@@ -123,6 +147,7 @@ func FindPostsHome(c *gin.Context) {
 				ID:   post.User.ID,
 				Name: post.User.Name,
 			},
+			Tags:      helpers.TagSimpleResponses(post.Tags),
 			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
 		})
@@ -17,7 +17,7 @@ func FindProducts(c *gin.Context) {
 	search, page, limit, offset := helpers.GetPaginationParams(c)
 	baseURL := helpers.BuildBaseURL(c)
 
-	query := database.DB.Preload("User").Model(&models.Product{})
+	query := database.DB.Preload("User").Preload("Media").Model(&models.Product{})
 	if search != "" {
 		query = query.Where("title LIKE ? OR owner LIKE ?", "%"+search+"%", "%"+search+"%")
 	}
@@ -40,6 +40,8 @@ func FindProducts(c *gin.Context) {
 			Title:   product.Title,
 			Slug:    product.Slug,
 			Image:   product.Image,
+			Images:  helpers.EntityImages("products", product.Image, product.Media),
+			Media:   helpers.MediaSimpleResponse(product.Media),
 			Owner:   product.Owner,
 			Price:   product.Price,
 			Address: product.Address,
@@ -60,7 +62,7 @@ func FindProductBySlug(c *gin.Context) {
 	slug := c.Param("slug")
 	var product models.Product
 
-	err := database.DB.Preload("User").First(&product, "slug = ?", slug).Error
+	err := database.DB.Preload("User").Preload("Media").First(&product, "slug = ?", slug).Error
 	if err != nil {
 		c.JSON(http.StatusNotFound, structs.ErrorResponse{
 			Success: false,
@@ -70,6 +72,8 @@ func FindProductBySlug(c *gin.Context) {
 		return
 	}
 
+	siteURL := helpers.SiteURL()
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Product found",
@@ -79,6 +83,8 @@ func FindProductBySlug(c *gin.Context) {
 			Slug:    product.Slug,
 			Content: product.Content,
 			Image:   product.Image,
+			Images:  helpers.EntityImages("products", product.Image, product.Media),
+			Media:   helpers.MediaSimpleResponse(product.Media),
 			Owner:   product.Owner,
 			Price:   product.Price,
 			Address: product.Address,
@@ -87,6 +93,7 @@ func FindProductBySlug(c *gin.Context) {
 				ID:   product.User.ID,
 				Name: product.User.Name,
 			},
+			SEO:       helpers.ResolveSEO(product.SEO, product.Title, product.Content, siteURL+"/products/"+product.Slug, helpers.EntityImageURL("products", product.Image, product.Media), "product"),
 			CreatedAt: product.CreatedAt.Format("2006-01-02 15:04:05"),
 			UpdatedAt: product.UpdatedAt.Format("2006-01-02 15:04:05"),
 		},
@@ -96,7 +103,7 @@ func FindProductBySlug(c *gin.Context) {
 func FindProductsHome(c *gin.Context) {
 	var products []models.Product
 
-	err := database.DB.Preload("User").Order("id DESC").Limit(6).Find(&products).Error
+	err := database.DB.Preload("User").Preload("Media").Order("id DESC").Limit(6).Find(&products).Error
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
@@ -113,6 +120,8 @@ func FindProductsHome(c *gin.Context) {
 			Title:   product.Title,
 			Slug:    product.Slug,
 			Image:   product.Image,
+			Images:  helpers.EntityImages("products", product.Image, product.Media),
+			Media:   helpers.MediaSimpleResponse(product.Media),
 			Owner:   product.Owner,
 			Price:   product.Price,
 			Address: product.Address,
@@ -0,0 +1,43 @@
+package public
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func Search(c *gin.Context) {
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	// pagination links carry the term as "search", so accept both names
+	query := strings.TrimSpace(c.DefaultQuery("q", search))
+	if query == "" {
+		c.JSON(http.StatusUnprocessableEntity, structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"q": "q is required"},
+		})
+		return
+	}
+
+	var types []string
+	if t := c.Query("type"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	results, total, err := helpers.SearchContent(query, types, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to search",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	helpers.PaginateResponse(c, results, total, page, limit, baseURL, query, "Search Results")
+}
@@ -0,0 +1,245 @@
+package public
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// sitemapLimit is the most URLs a single sitemap file may hold.
+const sitemapLimit = 50000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapSection struct {
+	name string
+	path string
+	// query selects slug and updated_at of the rows listed in the section
+	query func() *gorm.DB
+}
+
+type sitemapEntry struct {
+	Slug      string
+	UpdatedAt time.Time
+}
+
+var sitemapSections = []sitemapSection{
+	{name: "posts", path: "/posts/", query: func() *gorm.DB {
+		return database.DB.Model(&models.Post{}).Scopes(helpers.PublishedPosts)
+	}},
+	{name: "pages", path: "/pages/", query: func() *gorm.DB {
+		return database.DB.Model(&models.Page{})
+	}},
+	{name: "products", path: "/products/", query: func() *gorm.DB {
+		return database.DB.Model(&models.Product{})
+	}},
+}
+
+func Sitemap(c *gin.Context) {
+	siteURL := helpers.SiteURL()
+
+	var total int64
+	counts := map[string]int64{}
+	for _, section := range sitemapSections {
+		var count int64
+		if err := section.query().Count(&count).Error; err != nil {
+			sitemapError(c, err)
+			return
+		}
+		counts[section.name] = count
+		total += count
+	}
+
+	// the home page is the one extra URL, listed first on a single sitemap
+	// and in the first file of an index
+	if total+1 <= sitemapLimit {
+		urls := []structs.SitemapURL{{Loc: siteURL + "/"}}
+		var lastModified time.Time
+		for _, section := range sitemapSections {
+			entries, err := sitemapEntries(section, 0)
+			if err != nil {
+				sitemapError(c, err)
+				return
+			}
+			urls = append(urls, sitemapURLs(siteURL, section, entries)...)
+			lastModified = latestUpdate(lastModified, entries)
+		}
+		writeSitemap(c, structs.SitemapURLSet{XMLNS: sitemapNS, URLs: urls}, lastModified)
+		return
+	}
+
+	index := structs.SitemapIndex{XMLNS: sitemapNS, Sitemaps: []structs.SitemapPointer{}}
+	var lastModified time.Time
+	for _, section := range sitemapSections {
+		for chunk := 1; chunk <= sitemapChunks(section, counts[section.name]); chunk++ {
+			offset, limit := sitemapWindow(section, chunk-1)
+
+			var updatedAt time.Time
+			err := section.query().
+				Select("MAX(updated_at)").
+				Where("id IN (?)", section.query().Select("id").Order("id").Limit(limit).Offset(offset)).
+				Scan(&updatedAt).Error
+			if err != nil {
+				sitemapError(c, err)
+				return
+			}
+			if updatedAt.After(lastModified) {
+				lastModified = updatedAt
+			}
+
+			pointer := structs.SitemapPointer{Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", siteURL, section.name, chunk)}
+			if !updatedAt.IsZero() {
+				pointer.LastMod = updatedAt.Format(time.RFC3339)
+			}
+			index.Sitemaps = append(index.Sitemaps, pointer)
+		}
+	}
+
+	writeSitemap(c, index, lastModified)
+}
+
+// SitemapChunk serves one file of the sitemap index, e.g. posts-2.xml.
+func SitemapChunk(c *gin.Context) {
+	name := strings.TrimSuffix(c.Param("file"), ".xml")
+
+	separator := strings.LastIndex(name, "-")
+	if separator < 0 {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	chunk, err := strconv.Atoi(name[separator+1:])
+	if err != nil || chunk < 1 {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	for _, section := range sitemapSections {
+		if section.name != name[:separator] {
+			continue
+		}
+
+		entries, err := sitemapEntries(section, chunk-1)
+		if err != nil {
+			sitemapError(c, err)
+			return
+		}
+		home := section.name == sitemapSections[0].name && chunk == 1
+		if len(entries) == 0 && !home {
+			c.Status(http.StatusNotFound)
+			return
+		}
+
+		siteURL := helpers.SiteURL()
+		urls := sitemapURLs(siteURL, section, entries)
+		if home {
+			urls = append([]structs.SitemapURL{{Loc: siteURL + "/"}}, urls...)
+		}
+		writeSitemap(c, structs.SitemapURLSet{XMLNS: sitemapNS, URLs: urls}, latestUpdate(time.Time{}, entries))
+		return
+	}
+
+	c.Status(http.StatusNotFound)
+}
+
+func Robots(c *gin.Context) {
+	var b strings.Builder
+
+	b.WriteString("User-agent: *\n")
+	if config.GetEnv("ROBOTS_DISALLOW_ALL", "false") == "true" {
+		// staging and other non-public deployments stay out of the index
+		b.WriteString("Disallow: /\n")
+	} else {
+		for _, path := range strings.Split(config.GetEnv("ROBOTS_DISALLOW", "/api/admin/"), ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				b.WriteString("Disallow: " + path + "\n")
+			}
+		}
+		b.WriteString("\nSitemap: " + helpers.SiteURL() + "/sitemap.xml\n")
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(b.String()))
+}
+
+// sitemapWindow returns the offset and limit of the rows in a chunk,
+// counted from 0. The first chunk of the first section also lists the home
+// page, so it holds one row less and the later chunks shift by one.
+func sitemapWindow(section sitemapSection, chunk int) (int, int) {
+	if section.name != sitemapSections[0].name {
+		return chunk * sitemapLimit, sitemapLimit
+	}
+	if chunk == 0 {
+		return 0, sitemapLimit - 1
+	}
+	return chunk*sitemapLimit - 1, sitemapLimit
+}
+
+// sitemapChunks is how many files of the index a section with count rows
+// takes.
+func sitemapChunks(section sitemapSection, count int64) int {
+	if section.name == sitemapSections[0].name {
+		count++
+	}
+	return int((count + sitemapLimit - 1) / sitemapLimit)
+}
+
+func sitemapEntries(section sitemapSection, chunk int) ([]sitemapEntry, error) {
+	offset, limit := sitemapWindow(section, chunk)
+
+	var entries []sitemapEntry
+	err := section.query().
+		Select("slug, updated_at").
+		Order("id").
+		Limit(limit).
+		Offset(offset).
+		Scan(&entries).Error
+	return entries, err
+}
+
+func sitemapURLs(siteURL string, section sitemapSection, entries []sitemapEntry) []structs.SitemapURL {
+	urls := []structs.SitemapURL{}
+	for _, entry := range entries {
+		urls = append(urls, structs.SitemapURL{
+			Loc:     siteURL + section.path + entry.Slug,
+			LastMod: entry.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return urls
+}
+
+func latestUpdate(last time.Time, entries []sitemapEntry) time.Time {
+	for _, entry := range entries {
+		if entry.UpdatedAt.After(last) {
+			last = entry.UpdatedAt
+		}
+	}
+	return last
+}
+
+func writeSitemap(c *gin.Context, sitemap any, lastModified time.Time) {
+	body, err := xml.MarshalIndent(sitemap, "", "  ")
+	if err != nil {
+		sitemapError(c, err)
+		return
+	}
+
+	helpers.WriteCacheable(c, "application/xml; charset=utf-8", append([]byte(xml.Header), body...), lastModified)
+}
+
+func sitemapError(c *gin.Context, err error) {
+	c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+		Success: false,
+		Message: "Failed to build sitemap",
+		Errors:  helpers.TranslateErrorMessage(err),
+	})
+}
@@ -13,7 +13,7 @@ import (
 func FindSliders(c *gin.Context) {
 	var sliders []models.Slider
 
-	if err := database.DB.Find(&sliders).Error; err != nil {
+	if err := database.DB.Preload("Media").Find(&sliders).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
 			Success: false,
 			Message: "Failed to fetch sliders",
@@ -22,9 +22,22 @@ func FindSliders(c *gin.Context) {
 		return
 	}
 
+	sliderResponses := []structs.SliderResponse{}
+	for _, slider := range sliders {
+		sliderResponses = append(sliderResponses, structs.SliderResponse{
+			ID:          slider.ID,
+			Image:       slider.Image,
+			Images:      helpers.EntityImages("sliders", slider.Image, slider.Media),
+			Media:       helpers.MediaSimpleResponse(slider.Media),
+			Description: slider.Description,
+			CreatedAt:   slider.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:   slider.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
 	c.JSON(http.StatusOK, structs.SuccessResponse{
 		Success: true,
 		Message: "Lists Data Sliders",
-		Data:    sliders,
+		Data:    sliderResponses,
 	})
 }
@@ -0,0 +1,111 @@
+package public
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+func FindTags(c *gin.Context) {
+	var tags []structs.TagResponse
+
+	// only published posts count, so drafts don't leak through the numbers
+	err := database.DB.Model(&models.Tag{}).
+		Select("tags.id, tags.name, tags.slug, COUNT(posts.id) AS posts_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.status = ? AND posts.published_at <= ? AND posts.deleted_at IS NULL", models.PostStatusPublished, time.Now()).
+		Group("tags.id").
+		Having("COUNT(posts.id) > 0").
+		Order("posts_count DESC, tags.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch tags",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	if tags == nil {
+		tags = []structs.TagResponse{}
+	}
+
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: "List Data Tags",
+		Data:    tags,
+	})
+}
+
+func FindPostsByTag(c *gin.Context) {
+	slug := c.Param("slug")
+	var tag models.Tag
+
+	if err := database.DB.First(&tag, "slug = ?", slug).Error; err != nil {
+		c.JSON(http.StatusNotFound, structs.ErrorResponse{
+			Success: false,
+			Message: "Tag not found",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	var posts []models.Post
+	var total int64
+
+	search, page, limit, offset := helpers.GetPaginationParams(c)
+	baseURL := helpers.BuildBaseURL(c)
+
+	query := database.DB.Preload("Category").Preload("User").Preload("Tags").Preload("Media").Model(&models.Post{}).
+		Scopes(helpers.PublishedPosts).
+		Joins("JOIN post_tags ON post_tags.post_id = posts.id AND post_tags.tag_id = ?", tag.ID)
+	if search != "" {
+		query = query.Where("title LIKE ?", "%"+search+"%")
+	}
+	query.Count(&total)
+
+	err := query.Order("published_at DESC, id DESC").Limit(limit).Offset(offset).Find(&posts).Error
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, structs.ErrorResponse{
+			Success: false,
+			Message: "Failed to fetch posts",
+			Errors:  helpers.TranslateErrorMessage(err),
+		})
+		return
+	}
+
+	postResponses := []structs.PostWithRelationResponse{}
+	for _, post := range posts {
+		postResponses = append(postResponses, structs.PostWithRelationResponse{
+			ID:            post.ID,
+			Image:         post.Image,
+			Images:        helpers.EntityImages("posts", post.Image, post.Media),
+			Media:         helpers.MediaSimpleResponse(post.Media),
+			Title:         post.Title,
+			Slug:          post.Slug,
+			Content:       post.Content,
+			ContentFormat: post.ContentFormat,
+			ContentHTML:   post.ContentHTML,
+			PublishedAt:   helpers.FormatNullableTime(post.PublishedAt),
+			Category: structs.CategorySimpleResponse{
+				ID:   post.Category.ID,
+				Name: post.Category.Name,
+			},
+			User: structs.UserSimpleResponse{
+				ID:   post.User.ID,
+				Name: post.User.Name,
+			},
+			Tags:      helpers.TagSimpleResponses(post.Tags),
+			CreatedAt: post.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt: post.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	helpers.PaginateResponse(c, postResponses, total, page, limit, baseURL, search, "List Data Posts By Tag "+tag.Name)
+}
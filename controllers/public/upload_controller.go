@@ -0,0 +1,50 @@
+package public
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/ahmadalaik/desa-digital/storage"
+	"github.com/gin-gonic/gin"
+)
+
+// ServeUpload streams a stored file for drivers that aren't the local disk,
+// so /static links saved before the switch keep working.
+func ServeUpload(c *gin.Context) {
+	key := c.Param("filepath")
+
+	object, err := storage.Default.Stat(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotExist) {
+			c.Status(http.StatusNotFound)
+		} else {
+			c.Status(http.StatusBadGateway)
+		}
+		return
+	}
+
+	reader, err := storage.Default.Get(key)
+	if err != nil {
+		c.Status(http.StatusBadGateway)
+		return
+	}
+	defer reader.Close()
+
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Header("Content-Length", strconv.FormatInt(object.Size, 10))
+	if !object.ModTime.IsZero() {
+		c.Header("Last-Modified", object.ModTime.UTC().Format(http.TimeFormat))
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	io.Copy(c.Writer, reader)
+}
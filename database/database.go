@@ -28,9 +28,38 @@ func InitDB() {
 	DB = db
 	fmt.Println("Database connected successfully!")
 
-	err = DB.AutoMigrate(&models.User{}, &models.Role{}, &models.Permission{}, &models.Category{}, &models.Post{}, &models.Slider{}, &models.Page{}, &models.Photo{}, &models.Aparatur{}, &models.Product{})
+	dropSoftDeleteSlugConstraints(DB)
+
+	err = DB.AutoMigrate(&models.User{}, &models.Role{}, &models.Permission{}, &models.Category{}, &models.Tag{}, &models.Post{}, &models.Comment{}, &models.Slider{}, &models.Page{}, &models.Album{}, &models.Photo{}, &models.Aparatur{}, &models.Product{}, &models.Session{}, &models.RefreshToken{}, &models.PasswordReset{}, &models.RecoveryCode{}, &models.Setting{}, &models.LoginAttempt{}, &models.AuditLog{}, &models.Revision{}, &models.Media{}, &models.MediaUsage{}, &models.UploadSession{})
 	if err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+	// posts created before the status column existed were already public
+	DB.Model(&models.Post{}).
+		Where("status = ? AND published_at IS NULL", models.PostStatusPublished).
+		Update("published_at", gorm.Expr("created_at"))
+	// content written before Markdown support is its own rendered HTML
+	for _, model := range []any{&models.Post{}, &models.Page{}} {
+		DB.Model(model).
+			Where("content_html IS NULL OR content_html = ''").
+			Update("content_html", gorm.Expr("content"))
+	}
+
+	migrateSearch(DB)
+
 	fmt.Println("Database migrate successfully!")
 }
+
+// dropSoftDeleteSlugConstraints removes the table wide unique constraint on
+// slugs of trashable content, a partial index over the rows that aren't
+// trashed replaces it. It runs before AutoMigrate, which can't tell the
+// constraint names older versions created apart.
+func dropSoftDeleteSlugConstraints(db *gorm.DB) {
+	for _, table := range []string{"posts", "pages", "products", "albums"} {
+		for _, constraint := range []string{"uni_" + table + "_slug", table + "_slug_key"} {
+			if err := db.Exec(fmt.Sprintf("ALTER TABLE IF EXISTS %s DROP CONSTRAINT IF EXISTS %s", table, constraint)).Error; err != nil {
+				log.Fatal("Failed to migrate database:", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SearchConfig is the text search configuration used for every search
+// vector. It copies the Indonesian snowball stemmer when the server ships
+// one and falls back to the language neutral "simple" configuration.
+const SearchConfig = "desa_search"
+
+// searchColumns lists the weighted text that feeds each table's search
+// vector. Posts and pages use the rendered HTML so Markdown syntax is never
+// indexed, and HTML tags are stripped so markup never matches a query.
+var searchColumns = map[string]string{
+	"posts": "setweight(to_tsvector('desa_search', coalesce(title, '')), 'A') || " +
+		"setweight(to_tsvector('desa_search', regexp_replace(coalesce(content_html, ''), '<[^>]*>', ' ', 'g')), 'B')",
+	"pages": "setweight(to_tsvector('desa_search', coalesce(title, '')), 'A') || " +
+		"setweight(to_tsvector('desa_search', regexp_replace(coalesce(content_html, ''), '<[^>]*>', ' ', 'g')), 'B')",
+	"products": "setweight(to_tsvector('desa_search', coalesce(title, '')), 'A') || " +
+		"setweight(to_tsvector('desa_search', coalesce(owner, '')), 'C') || " +
+		"setweight(to_tsvector('desa_search', regexp_replace(coalesce(content, ''), '<[^>]*>', ' ', 'g')), 'B')",
+	"photos": "setweight(to_tsvector('desa_search', coalesce(caption, '')), 'A') || " +
+		"setweight(to_tsvector('desa_search', coalesce(description, '')), 'B')",
+	"aparaturs": "setweight(to_tsvector('desa_search', coalesce(name, '')), 'A') || " +
+		"setweight(to_tsvector('desa_search', coalesce(position, '')), 'B') || " +
+		"setweight(to_tsvector('desa_search', coalesce(description, '')), 'C')",
+}
+
+func migrateSearch(db *gorm.DB) {
+	err := db.Exec(`
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'desa_search') THEN
+		IF EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'indonesian') THEN
+			CREATE TEXT SEARCH CONFIGURATION desa_search (COPY = pg_catalog.indonesian);
+		ELSE
+			CREATE TEXT SEARCH CONFIGURATION desa_search (COPY = pg_catalog.simple);
+		END IF;
+	END IF;
+END
+$$;`).Error
+	if err != nil {
+		log.Fatal("Failed to create search configuration:", err)
+	}
+
+	// generated columns keep the vectors in sync on every insert and update.
+	// The expression is kept as the column comment, a column built from an
+	// older expression is dropped and generated again.
+	for table, expression := range searchColumns {
+		var current string
+		err := db.Raw(`SELECT coalesce(col_description(attrelid, attnum), '') FROM pg_attribute
+			WHERE attrelid = ?::regclass AND attname = 'search_vector' AND NOT attisdropped`, table).Scan(&current).Error
+		if err != nil {
+			log.Fatalf("Failed to migrate search on %s: %v", table, err)
+		}
+
+		statements := []string{}
+		if current != expression {
+			statements = append(statements,
+				fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS search_vector", table),
+				fmt.Sprintf("ALTER TABLE %s ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (%s) STORED", table, expression),
+				fmt.Sprintf("COMMENT ON COLUMN %s.search_vector IS '%s'", table, strings.ReplaceAll(expression, "'", "''")),
+			)
+		}
+		statements = append(statements,
+			fmt.Sprintf("CREATE INDEX IF NOT EXISTS idx_%s_search_vector ON %s USING GIN (search_vector)", table, table))
+
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				log.Fatalf("Failed to migrate search on %s: %v", table, err)
+			}
+		}
+	}
+}
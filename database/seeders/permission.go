@@ -37,6 +37,13 @@ func SeedPermissions(db *gorm.DB) {
 		{Name: "categories-update"},
 		{Name: "categories-delete"},
 
+		{Name: "tags-index"},
+		{Name: "tags-create"},
+		{Name: "tags-show"},
+		{Name: "tags-edit"},
+		{Name: "tags-update"},
+		{Name: "tags-delete"},
+
 		{Name: "posts-index"},
 		{Name: "posts-create"},
 		{Name: "posts-show"},
@@ -44,6 +51,11 @@ func SeedPermissions(db *gorm.DB) {
 		{Name: "posts-update"},
 		{Name: "posts-delete"},
 
+		{Name: "comments-index"},
+		{Name: "comments-show"},
+		{Name: "comments-update"},
+		{Name: "comments-delete"},
+
 		{Name: "products-index"},
 		{Name: "products-create"},
 		{Name: "products-show"},
@@ -60,8 +72,15 @@ func SeedPermissions(db *gorm.DB) {
 
 		{Name: "photos-index"},
 		{Name: "photos-create"},
+		{Name: "photos-update"},
 		{Name: "photos-delete"},
 
+		{Name: "albums-index"},
+		{Name: "albums-create"},
+		{Name: "albums-show"},
+		{Name: "albums-update"},
+		{Name: "albums-delete"},
+
 		{Name: "aparaturs-index"},
 		{Name: "aparaturs-create"},
 		{Name: "aparaturs-show"},
@@ -72,6 +91,21 @@ func SeedPermissions(db *gorm.DB) {
 		{Name: "sliders-index"},
 		{Name: "sliders-create"},
 		{Name: "sliders-delete"},
+
+		{Name: "media-index"},
+		{Name: "media-create"},
+		{Name: "media-show"},
+		{Name: "media-update"},
+		{Name: "media-delete"},
+
+		{Name: "uploads-create"},
+
+		{Name: "settings-index"},
+		{Name: "settings-update"},
+
+		{Name: "storage-reconcile"},
+
+		{Name: "audit-index"},
 	}
 
 	for _, p := range permissions {
@@ -22,7 +22,7 @@ func SeedRoles(db *gorm.DB) {
 			db.Model(&role).Association("Permissions").Replace(allPermissions)
 		case "user":
 			var viewOnly []models.Permission
-			db.Where("name IN ?", []string{"posts-index", "photos-index", "sliders-index", "pages-index"}).Find(&viewOnly)
+			db.Where("name IN ?", []string{"posts-index", "photos-index", "albums-index", "sliders-index", "pages-index"}).Find(&viewOnly)
 			db.Model(&role).Association("Permissions").Replace(viewOnly)
 		}
 
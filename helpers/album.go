@@ -0,0 +1,94 @@
+package helpers
+
+import (
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+// AlbumPhotoStats counts the photos of each album and picks its first
+// photo, which stands in as cover for albums without one of their own.
+func AlbumPhotoStats(albumIDs []uint) (map[uint]int64, map[uint]models.Photo) {
+	counts := map[uint]int64{}
+	covers := map[uint]models.Photo{}
+	if len(albumIDs) == 0 {
+		return counts, covers
+	}
+
+	var rows []struct {
+		AlbumID uint
+		Total   int64
+	}
+	database.DB.Model(&models.Photo{}).
+		Select("album_id, COUNT(*) AS total").
+		Where("album_id IN ?", albumIDs).
+		Group("album_id").
+		Scan(&rows)
+	for _, row := range rows {
+		counts[row.AlbumID] = row.Total
+	}
+
+	var photos []models.Photo
+	database.DB.Raw("SELECT DISTINCT ON (album_id) * FROM photos WHERE album_id IN ? AND deleted_at IS NULL ORDER BY album_id, sort_order, id", albumIDs).
+		Scan(&photos)
+	for _, photo := range photos {
+		covers[*photo.AlbumID] = photo
+	}
+
+	return counts, covers
+}
+
+func AlbumResponse(c *gin.Context, album models.Album, photosCount int64, firstPhoto *models.Photo) structs.AlbumResponse {
+	cover := EntityImages("albums", album.Image, album.Media)
+	if cover == nil && firstPhoto != nil {
+		cover = ImageURLs("photos", firstPhoto.Image)
+	}
+
+	return structs.AlbumResponse{
+		ID:          album.ID,
+		Title:       album.Title,
+		Slug:        album.Slug,
+		Description: album.Description,
+		Image:       album.Image,
+		Cover:       cover,
+		Media:       MediaSimpleResponse(album.Media),
+		EventDate:   FormatNullableDate(album.EventDate),
+		SortOrder:   album.SortOrder,
+		PhotosCount: photosCount,
+		CreatedAt:   album.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   album.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func PhotoResponse(c *gin.Context, photo models.Photo) structs.PhotoResponse {
+	return structs.PhotoResponse{
+		ID:          photo.ID,
+		Image:       photo.Image,
+		Images:      ImageURLs("photos", photo.Image),
+		Caption:     photo.Caption,
+		Description: photo.Description,
+		AlbumID:     photo.AlbumID,
+		SortOrder:   photo.SortOrder,
+		CreatedAt:   photo.CreatedAt.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   photo.UpdatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+func AlbumResponses(c *gin.Context, albums []models.Album) []structs.AlbumResponse {
+	ids := []uint{}
+	for _, album := range albums {
+		ids = append(ids, album.ID)
+	}
+	counts, covers := AlbumPhotoStats(ids)
+
+	responses := []structs.AlbumResponse{}
+	for _, album := range albums {
+		var firstPhoto *models.Photo
+		if cover, ok := covers[album.ID]; ok {
+			firstPhoto = &cover
+		}
+		responses = append(responses, AlbumResponse(c, album, counts[album.ID], firstPhoto))
+	}
+	return responses
+}
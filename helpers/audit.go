@@ -0,0 +1,124 @@
+package helpers
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	AuditActionCreate    = "create"
+	AuditActionUpdate    = "update"
+	AuditActionDelete    = "delete"
+	AuditActionRestore   = "restore"
+	AuditActionPurge     = "purge"
+	AuditActionReconcile = "reconcile"
+	AuditActionUnlock    = "unlock"
+)
+
+// AuditSystemUsername is recorded for changes no user made.
+const AuditSystemUsername = "system"
+
+type AuditChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// RecordAudit stores who changed which entity and how. before is nil for
+// creates and after is nil for deletes. Failures are logged and never break
+// the request that triggered them.
+func RecordAudit(c *gin.Context, action, entityType string, entityID uint, before, after any) {
+	entry := auditEntry(action, entityType, entityID, before, after)
+	entry.IPAddress = c.ClientIP()
+	entry.UserAgent = c.Request.UserAgent()
+
+	if username, ok := c.Get("username"); ok {
+		entry.Username, _ = username.(string)
+	}
+	if userID, ok := AuthUserID(c); ok {
+		entry.UserID = &userID
+	}
+
+	saveAudit(entry)
+}
+
+// RecordSystemAudit stores a change made outside of a request, such as the
+// scheduled trash purge or a reconcile run from the command line.
+func RecordSystemAudit(action, entityType string, entityID uint, before, after any) {
+	entry := auditEntry(action, entityType, entityID, before, after)
+	entry.Username = AuditSystemUsername
+
+	saveAudit(entry)
+}
+
+func auditEntry(action, entityType string, entityID uint, before, after any) models.AuditLog {
+	beforeMap := auditSnapshot(before)
+	afterMap := auditSnapshot(after)
+
+	return models.AuditLog{
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Before:     auditJSON(beforeMap),
+		After:      auditJSON(afterMap),
+		Changes:    auditJSON(AuditDiff(beforeMap, afterMap)),
+	}
+}
+
+func saveAudit(entry models.AuditLog) {
+	if err := database.DB.Create(&entry).Error; err != nil {
+		log.Println("failed to write audit log:", err)
+	}
+}
+
+// AuditDiff returns the fields whose value differs between two snapshots,
+// updated_at is ignored because it changes on every save.
+func AuditDiff(before, after map[string]any) map[string]AuditChange {
+	changes := map[string]AuditChange{}
+
+	for key, to := range after {
+		if key == "updated_at" {
+			continue
+		}
+		if from, ok := before[key]; !ok || !reflect.DeepEqual(from, to) {
+			changes[key] = AuditChange{From: before[key], To: to}
+		}
+	}
+
+	for key, from := range before {
+		if _, ok := after[key]; !ok && key != "updated_at" {
+			changes[key] = AuditChange{From: from, To: nil}
+		}
+	}
+
+	return changes
+}
+
+func auditSnapshot(value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var snapshot map[string]any
+	if err := json.Unmarshal(raw, &snapshot); err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+func auditJSON(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "null"
+	}
+	return string(raw)
+}
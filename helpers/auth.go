@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthUserID resolves the id of the user behind the current request from
+// the username AuthMiddleware put in the context.
+func AuthUserID(c *gin.Context) (uint, bool) {
+	username, ok := c.Get("username")
+	if !ok {
+		return 0, false
+	}
+
+	var user models.User
+	if err := database.DB.Select("id").Where("username = ?", username).First(&user).Error; err != nil {
+		return 0, false
+	}
+	return user.ID, true
+}
@@ -0,0 +1,50 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+// maxCommentLinks is how many links a comment may carry before it goes
+// straight to the spam queue.
+const maxCommentLinks = 2
+
+func IsLikelySpamComment(content string) bool {
+	lower := strings.ToLower(content)
+	return strings.Count(lower, "http://")+strings.Count(lower, "https://") > maxCommentLinks
+}
+
+// CommentTree nests comments under their parents. Replies whose parent is
+// not part of the list (e.g. not approved) are dropped with it.
+func CommentTree(comments []models.Comment) []structs.CommentResponse {
+	children := map[uint][]models.Comment{}
+	roots := []models.Comment{}
+
+	for _, comment := range comments {
+		if comment.ParentID == nil {
+			roots = append(roots, comment)
+		} else {
+			children[*comment.ParentID] = append(children[*comment.ParentID], comment)
+		}
+	}
+
+	var build func(list []models.Comment) []structs.CommentResponse
+	build = func(list []models.Comment) []structs.CommentResponse {
+		responses := []structs.CommentResponse{}
+		for _, comment := range list {
+			responses = append(responses, structs.CommentResponse{
+				ID:        comment.ID,
+				ParentID:  comment.ParentID,
+				Name:      comment.Name,
+				Content:   comment.Content,
+				Replies:   build(children[comment.ID]),
+				CreatedAt: comment.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+		return responses
+	}
+
+	return build(roots)
+}
@@ -0,0 +1,25 @@
+package helpers
+
+import "github.com/ahmadalaik/desa-digital/models"
+
+// RenderContent returns the source to store and the sanitised HTML served
+// to readers. Markdown sources are kept as written so editors can keep
+// working on them; HTML is sanitised in both.
+func RenderContent(contentType, format, source string) (string, string) {
+	if format == models.ContentFormatMarkdown {
+		return source, SanitizeContent(contentType, RenderMarkdown(source))
+	}
+
+	sanitized := SanitizeContent(contentType, source)
+	return sanitized, sanitized
+}
+
+func ResolveContentFormat(requested, current string) string {
+	if requested != "" {
+		return requested
+	}
+	if current != "" {
+		return current
+	}
+	return models.ContentFormatHTML
+}
@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+const (
+	DiffEqual  = "equal"
+	DiffInsert = "insert"
+	DiffDelete = "delete"
+)
+
+// maxDiffCells bounds the LCS table so a huge document can't exhaust memory,
+// past it the diff degrades to "everything removed, everything added".
+const maxDiffCells = 4_000_000
+
+// DiffLines returns a line based diff between two texts using the longest
+// common subsequence of their lines.
+func DiffLines(from, to string) []structs.DiffLine {
+	a := splitDiffLines(from)
+	b := splitDiffLines(to)
+
+	if len(a)*len(b) > maxDiffCells {
+		diff := make([]structs.DiffLine, 0, len(a)+len(b))
+		for _, line := range a {
+			diff = append(diff, structs.DiffLine{Type: DiffDelete, Text: line})
+		}
+		for _, line := range b {
+			diff = append(diff, structs.DiffLine{Type: DiffInsert, Text: line})
+		}
+		return diff
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	diff := []structs.DiffLine{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, structs.DiffLine{Type: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, structs.DiffLine{Type: DiffDelete, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, structs.DiffLine{Type: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, structs.DiffLine{Type: DiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, structs.DiffLine{Type: DiffInsert, Text: b[j]})
+	}
+
+	return diff
+}
+
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
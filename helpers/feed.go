@@ -0,0 +1,177 @@
+package helpers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+type FeedChannel struct {
+	Title       string
+	Description string
+	SiteURL     string
+	// SelfURL is the feed's own address without extension, ".rss" and
+	// ".atom" are appended per format.
+	SelfURL string
+	Posts   []models.Post
+}
+
+// PostGUID is a tag URI that survives slug changes, so readers don't show
+// an edited post as a new item.
+func PostGUID(siteURL string, post models.Post) string {
+	host := siteURL
+	if u, err := urlHost(siteURL); err == nil {
+		host = u
+	}
+	return fmt.Sprintf("tag:%s,%s:post/%d", host, post.CreatedAt.Format("2006-01-02"), post.ID)
+}
+
+func PostURL(siteURL string, post models.Post) string {
+	return fmt.Sprintf("%s/posts/%s", siteURL, post.Slug)
+}
+
+func postPublished(post models.Post) time.Time {
+	if post.PublishedAt != nil {
+		return *post.PublishedAt
+	}
+	return post.CreatedAt
+}
+
+// FeedLastModified is the most recent change among the posts of a feed.
+func FeedLastModified(posts []models.Post) time.Time {
+	var last time.Time
+	for _, post := range posts {
+		if post.UpdatedAt.After(last) {
+			last = post.UpdatedAt
+		}
+		if published := postPublished(post); published.After(last) {
+			last = published
+		}
+	}
+	return last
+}
+
+func BuildRSS(channel FeedChannel) ([]byte, error) {
+	feed := structs.RSSFeed{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		Channel: structs.RSSChannel{
+			Title:       channel.Title,
+			Link:        channel.SiteURL,
+			Description: channel.Description,
+			Language:    "id",
+			AtomLink: structs.RSSAtomLink{
+				Href: channel.SelfURL + ".rss",
+				Rel:  "self",
+				Type: "application/rss+xml",
+			},
+			Items: []structs.RSSItem{},
+		},
+	}
+	if last := FeedLastModified(channel.Posts); !last.IsZero() {
+		feed.Channel.LastBuildDate = last.Format(time.RFC1123Z)
+	}
+
+	for _, post := range channel.Posts {
+		item := structs.RSSItem{
+			Title:          post.Title,
+			Link:           PostURL(channel.SiteURL, post),
+			GUID:           structs.RSSGUID{Value: PostGUID(channel.SiteURL, post), IsPermaLink: "false"},
+			Description:    Excerpt(post.ContentHTML, 300),
+			ContentEncoded: structs.RSSCDATA{Value: post.ContentHTML},
+			Category:       post.Category.Name,
+			PubDate:        postPublished(post).Format(time.RFC1123Z),
+		}
+		if enclosure, ok := postEnclosure(channel.SiteURL, post); ok {
+			item.Enclosure = &enclosure
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return marshalFeed(feed)
+}
+
+func BuildAtom(channel FeedChannel) ([]byte, error) {
+	updated := FeedLastModified(channel.Posts)
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	feed := structs.AtomFeed{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		ID:      channel.SelfURL + ".atom",
+		Title:   channel.Title,
+		Updated: updated.Format(time.RFC3339),
+		Links: []structs.AtomLink{
+			{Href: channel.SelfURL + ".atom", Rel: "self", Type: "application/atom+xml"},
+			{Href: channel.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: []structs.AtomEntry{},
+	}
+
+	for _, post := range channel.Posts {
+		entry := structs.AtomEntry{
+			ID:        PostGUID(channel.SiteURL, post),
+			Title:     post.Title,
+			Updated:   post.UpdatedAt.Format(time.RFC3339),
+			Published: postPublished(post).Format(time.RFC3339),
+			Links: []structs.AtomLink{
+				{Href: PostURL(channel.SiteURL, post), Rel: "alternate", Type: "text/html"},
+			},
+			Summary: structs.AtomText{Type: "text", Value: Excerpt(post.ContentHTML, 300)},
+			Content: structs.AtomText{Type: "html", Value: post.ContentHTML},
+		}
+		if post.User.Name != "" {
+			entry.Author = &structs.AtomAuthor{Name: post.User.Name}
+		}
+		if post.Category.Slug != "" {
+			entry.Category = &structs.AtomCategory{Term: post.Category.Slug, Label: post.Category.Name}
+		}
+		if enclosure, ok := postEnclosure(channel.SiteURL, post); ok {
+			entry.Links = append(entry.Links, structs.AtomLink{
+				Href: enclosure.URL,
+				Rel:  "enclosure",
+				Type: enclosure.Type,
+			})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return marshalFeed(feed)
+}
+
+// postEnclosure describes the post's own image or, failing that, the media
+// it uses. The size comes from the database; posts whose image predates
+// ImageSize report 0, which RSS allows when the length is unknown.
+func postEnclosure(siteURL string, post models.Post) (structs.RSSEnclosure, bool) {
+	switch {
+	case post.Image != "":
+		return structs.RSSEnclosure{
+			URL:    UploadURL(siteURL, "posts", post.Image),
+			Type:   mime.TypeByExtension(filepath.Ext(post.Image)),
+			Length: strconv.FormatInt(post.ImageSize, 10),
+		}, true
+	case post.Media != nil && post.Media.ID != 0:
+		return structs.RSSEnclosure{
+			URL:    UploadURL(siteURL, MediaDirectory, post.Media.FileName),
+			Type:   post.Media.MimeType,
+			Length: strconv.FormatInt(post.Media.Size, 10),
+		}, true
+	}
+	return structs.RSSEnclosure{}, false
+}
+
+func marshalFeed(feed any) ([]byte, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
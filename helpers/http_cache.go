@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WriteCacheable sends body with ETag and Last-Modified headers, answering
+// 304 Not Modified when the client's validators still match.
+func WriteCacheable(c *gin.Context, contentType string, body []byte, lastModified time.Time) {
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	lastModified = lastModified.UTC().Truncate(time.Second)
+
+	c.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+
+	if match := c.GetHeader("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				c.Status(http.StatusNotModified)
+				return
+			}
+		}
+	} else if since := c.GetHeader("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+		if t, err := http.ParseTime(since); err == nil && !lastModified.After(t) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, contentType, body)
+}
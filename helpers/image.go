@@ -0,0 +1,530 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"mime"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/storage"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+type ImageVariant struct {
+	Name  string
+	Width int
+}
+
+const imageJPEGQuality = 82
+
+var ErrImageTooLarge = errors.New("image dimensions exceed the allowed maximum")
+
+var imageExtensions = []string{".jpg", ".jpeg", ".png", ".gif"}
+
+// ImageVariants reads IMAGE_VARIANTS, a list of name:width pairs such as
+// "thumb:320,medium:800,large:1600".
+func ImageVariants() []ImageVariant {
+	variants := []ImageVariant{}
+	for _, pair := range strings.Split(config.GetEnv("IMAGE_VARIANTS", "thumb:320,medium:800,large:1600"), ",") {
+		name, width, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok {
+			continue
+		}
+		w, err := strconv.Atoi(width)
+		if err != nil || w <= 0 || name == "" {
+			continue
+		}
+		variants = append(variants, ImageVariant{Name: name, Width: w})
+	}
+	return variants
+}
+
+// MaxImagePixels is the largest width*height accepted for uploads, read from
+// UPLOAD_MAX_PIXELS. A small file can declare huge dimensions and exhaust
+// memory once decoded, so the header is checked first.
+func MaxImagePixels() int {
+	pixels, err := strconv.Atoi(config.GetEnv("UPLOAD_MAX_PIXELS", "40000000"))
+	if err != nil || pixels <= 0 {
+		return 40000000
+	}
+	return pixels
+}
+
+// ReencodeImage verifies data decodes as an image no larger than maxPixels
+// and encodes it again. Only pixels survive, so EXIF (GPS included) and any
+// other metadata or trailing bytes are dropped. JPEGs are rotated upright
+// first because their orientation tag is lost too.
+func ReencodeImage(data []byte, maxPixels int) ([]byte, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, errors.New("image has no dimensions")
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d", ErrImageTooLarge, cfg.Width, cfg.Height)
+	}
+
+	var out bytes.Buffer
+	switch format {
+	case "gif":
+		// DecodeAll keeps every frame in memory, so the frames are counted
+		// from the block headers before anything is decoded
+		if err := checkGIFFrames(data, maxPixels*10); err != nil {
+			return nil, err
+		}
+		anim, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		err = gif.EncodeAll(&out, anim)
+		return out.Bytes(), err
+	case "jpeg":
+		img, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		upright := applyOrientation(toRGBA(img), ExifOrientation(bytes.NewReader(data)))
+		err = jpeg.Encode(&out, upright, &jpeg.Options{Quality: 90})
+		return out.Bytes(), err
+	case "png":
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		err = png.Encode(&out, img)
+		return out.Bytes(), err
+	}
+
+	return nil, fmt.Errorf("unsupported image format %q", format)
+}
+
+// maxGIFFrames bounds the frame count on top of the pixel budget, each
+// frame costs an allocation however small it is.
+const maxGIFFrames = 1000
+
+var errInvalidGIF = errors.New("gif: malformed block structure")
+
+// checkGIFFrames walks the GIF block structure without decoding it and
+// stops as soon as the frames add up to more than maxPixels or
+// maxGIFFrames.
+func checkGIFFrames(data []byte, maxPixels int) error {
+	// header and logical screen descriptor
+	if len(data) < 13 {
+		return errInvalidGIF
+	}
+	pos := 13
+	if data[10]&0x80 != 0 {
+		pos += 3 << ((data[10] & 0x07) + 1)
+	}
+
+	frames, pixels := 0, 0
+	for pos < len(data) {
+		switch data[pos] {
+		case 0x21: // extension: label and sub-blocks
+			pos += 2
+		case 0x2c: // image descriptor
+			if pos+10 > len(data) {
+				return errInvalidGIF
+			}
+			width := int(binary.LittleEndian.Uint16(data[pos+5:]))
+			height := int(binary.LittleEndian.Uint16(data[pos+7:]))
+			packed := data[pos+9]
+
+			frames++
+			pixels += width * height
+			if frames > maxGIFFrames || pixels > maxPixels {
+				return fmt.Errorf("%w: %d frames, %d pixels", ErrImageTooLarge, frames, pixels)
+			}
+
+			pos += 10
+			if packed&0x80 != 0 {
+				pos += 3 << ((packed & 0x07) + 1)
+			}
+			// LZW minimum code size
+			pos++
+		case 0x3b: // trailer
+			return nil
+		default:
+			return errInvalidGIF
+		}
+
+		// skip the data sub-blocks
+		for {
+			if pos >= len(data) {
+				return errInvalidGIF
+			}
+			size := int(data[pos])
+			pos++
+			if size == 0 {
+				break
+			}
+			pos += size
+		}
+	}
+	return errInvalidGIF
+}
+
+func IsImageFile(fileName string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, e := range imageExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// VariantFileName is the file a variant of fileName is stored under. JPEG
+// sources stay JPEG, everything else becomes PNG to keep transparency.
+func VariantFileName(fileName, variant string) string {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	base := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	if ext != ".jpg" && ext != ".jpeg" {
+		ext = ".png"
+	}
+	return base + "-" + variant + ext
+}
+
+func WebPFileName(fileName, variant string) string {
+	return strings.TrimSuffix(fileName, filepath.Ext(fileName)) + "-" + variant + ".webp"
+}
+
+var webpEncoder = sync.OnceValue(func() string {
+	path, err := exec.LookPath(config.GetEnv("CWEBP_PATH", "cwebp"))
+	if err != nil {
+		log.Printf("cwebp not found (%v), images get no WebP variants", err)
+		return ""
+	}
+	return path
+})
+
+// WebPEnabled reports whether the cwebp encoder is installed, looking it up
+// once.
+func WebPEnabled() bool {
+	return webpEncoder() != ""
+}
+
+// ProcessImage stores every configured variant of an uploaded image next
+// to it, rotated upright from its EXIF orientation, plus WebP copies when
+// the cwebp encoder is installed. Variants of an animated GIF are stills of
+// its first frame, only the original keeps the animation.
+func ProcessImage(dir, fileName string, data []byte) error {
+	src, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	img := toRGBA(src)
+	if format == "jpeg" {
+		img = applyOrientation(img, ExifOrientation(bytes.NewReader(data)))
+	}
+
+	for _, variant := range ImageVariants() {
+		resized := img
+		if img.Bounds().Dx() > variant.Width {
+			height := img.Bounds().Dy() * variant.Width / img.Bounds().Dx()
+			resized = resizeRGBA(img, variant.Width, max(height, 1))
+		}
+
+		variantName := VariantFileName(fileName, variant.Name)
+		encoded, err := encodeImage(variantName, resized)
+		if err != nil {
+			return err
+		}
+		if err := putFile(dir, variantName, encoded); err != nil {
+			return err
+		}
+
+		if encoder := webpEncoder(); encoder != "" {
+			webpName := WebPFileName(fileName, variant.Name)
+			if err := encodeWebP(encoder, dir, webpName, variantName, encoded); err != nil {
+				log.Printf("failed to encode webp %s: %v", webpName, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// encodeWebP runs cwebp on a scratch copy of the variant, the storage
+// driver may not be a local disk the encoder can read from.
+func encodeWebP(encoder, dir, webpName, variantName string, variant []byte) error {
+	tmpDir, err := os.MkdirTemp("", "webp-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	input := filepath.Join(tmpDir, variantName)
+	output := filepath.Join(tmpDir, webpName)
+	if err := os.WriteFile(input, variant, 0600); err != nil {
+		return err
+	}
+	if out, err := exec.Command(encoder, "-quiet", "-q", "80", input, "-o", output).CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, out)
+	}
+
+	webp, err := os.ReadFile(output)
+	if err != nil {
+		return err
+	}
+	return putFile(dir, webpName, webp)
+}
+
+func putFile(dir, fileName string, data []byte) error {
+	return storage.Default.Put(storage.Key(dir, fileName), bytes.NewReader(data), int64(len(data)), mime.TypeByExtension(filepath.Ext(fileName)))
+}
+
+// ImageURLs lists the URLs of an upload and its variants. The variants are
+// derived from IMAGE_VARIANTS instead of being looked up in storage, since
+// ProcessImage makes every one of them for each image. Images stored before
+// a variant was configured get it from BackfillImageVariants, the
+// "variants" command.
+func ImageURLs(dir, fileName string) *structs.ImagesResponse {
+	if fileName == "" {
+		return nil
+	}
+
+	siteURL := SiteURL()
+	images := &structs.ImagesResponse{
+		Original: UploadURL(siteURL, dir, fileName),
+		Variants: map[string]structs.ImageVariantResponse{},
+	}
+	if !IsImageFile(fileName) {
+		return images
+	}
+
+	for _, variant := range ImageVariants() {
+		response := structs.ImageVariantResponse{
+			URL:   UploadURL(siteURL, dir, VariantFileName(fileName, variant.Name)),
+			Width: variant.Width,
+		}
+		if WebPEnabled() {
+			response.WebP = UploadURL(siteURL, dir, WebPFileName(fileName, variant.Name))
+		}
+		images.Variants[variant.Name] = response
+	}
+
+	return images
+}
+
+// RemoveUpload deletes an upload together with its generated variants.
+func RemoveUpload(dir, fileName string) error {
+	if fileName == "" {
+		return nil
+	}
+
+	names := []string{fileName}
+	for _, variant := range ImageVariants() {
+		names = append(names, VariantFileName(fileName, variant.Name), WebPFileName(fileName, variant.Name))
+	}
+
+	var firstErr error
+	for _, name := range names {
+		err := storage.Default.Delete(storage.Key(dir, name))
+		if err != nil && !errors.Is(err, storage.ErrNotExist) && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func encodeImage(fileName string, img image.Image) ([]byte, error) {
+	var out bytes.Buffer
+	var err error
+	if strings.HasSuffix(fileName, ".png") {
+		err = png.Encode(&out, img)
+	} else {
+		err = jpeg.Encode(&out, img, &jpeg.Options{Quality: imageJPEGQuality})
+	}
+	return out.Bytes(), err
+}
+
+func toRGBA(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Src)
+	return dst
+}
+
+// resizeRGBA scales down with area averaging: every destination pixel is
+// the coverage weighted mean of the source pixels beneath it. It runs as
+// two separable passes.
+func resizeRGBA(src *image.RGBA, width, height int) *image.RGBA {
+	horizontal := resampleAxis(src, width, src.Bounds().Dy(), true)
+	return resampleAxis(horizontal, width, height, false)
+}
+
+func resampleAxis(src *image.RGBA, width, height int, horizontal bool) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	srcLen, dstLen, lines := src.Bounds().Dx(), width, height
+	if !horizontal {
+		srcLen, dstLen, lines = src.Bounds().Dy(), height, width
+	}
+	scale := float64(srcLen) / float64(dstLen)
+
+	for d := 0; d < dstLen; d++ {
+		start, end := float64(d)*scale, float64(d+1)*scale
+		first, last := int(start), min(int(end+0.999999), srcLen)
+
+		for line := 0; line < lines; line++ {
+			var r, g, b, a, total float64
+			for s := first; s < last; s++ {
+				weight := min(end, float64(s+1)) - max(start, float64(s))
+				if weight <= 0 {
+					continue
+				}
+
+				var offset int
+				if horizontal {
+					offset = src.PixOffset(s, line)
+				} else {
+					offset = src.PixOffset(line, s)
+				}
+				r += float64(src.Pix[offset]) * weight
+				g += float64(src.Pix[offset+1]) * weight
+				b += float64(src.Pix[offset+2]) * weight
+				a += float64(src.Pix[offset+3]) * weight
+				total += weight
+			}
+
+			var offset int
+			if horizontal {
+				offset = dst.PixOffset(d, line)
+			} else {
+				offset = dst.PixOffset(line, d)
+			}
+			dst.Pix[offset] = uint8(r/total + 0.5)
+			dst.Pix[offset+1] = uint8(g/total + 0.5)
+			dst.Pix[offset+2] = uint8(b/total + 0.5)
+			dst.Pix[offset+3] = uint8(a/total + 0.5)
+		}
+	}
+
+	return dst
+}
+
+// applyOrientation turns an image upright according to the EXIF
+// orientation values 2 to 8; 1 and unknown values leave it untouched.
+func applyOrientation(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], src.Pix[src.PixOffset(x, y):src.PixOffset(x, y)+4])
+		}
+	}
+
+	return dst
+}
+
+// ExifOrientation returns the orientation tag of a JPEG, or 1 when the
+// file has none.
+func ExifOrientation(r io.Reader) int {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil || header[0] != 0xFF || header[1] != 0xD8 {
+		return 1
+	}
+
+	for {
+		marker := make([]byte, 4)
+		if _, err := io.ReadFull(r, marker); err != nil || marker[0] != 0xFF {
+			return 1
+		}
+		// start of scan, the metadata segments are behind us
+		if marker[1] == 0xDA || marker[1] == 0xD9 {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(marker[2:])) - 2
+		if length < 0 {
+			return 1
+		}
+		segment := make([]byte, length)
+		if _, err := io.ReadFull(r, segment); err != nil {
+			return 1
+		}
+
+		if marker[1] == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+	}
+}
+
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
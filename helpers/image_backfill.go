@@ -0,0 +1,113 @@
+package helpers
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"path"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/storage"
+)
+
+// ImageBackfillReport sums up a BackfillImageVariants run.
+type ImageBackfillReport struct {
+	Scanned   int
+	Processed []string
+	Failed    map[string]string
+}
+
+// BackfillImageVariants runs ProcessImage over the stored images that lack
+// one of the variants ImageURLs returns, such as uploads from before
+// variants existed or from before a variant was added to IMAGE_VARIANTS.
+// With force every image is processed again.
+func BackfillImageVariants(force bool) (ImageBackfillReport, error) {
+	report := ImageBackfillReport{Failed: map[string]string{}}
+
+	files := map[string][]string{}
+	var dirs []string
+	for _, source := range reconcileSources {
+		var names []string
+		err := database.DB.Unscoped().Model(source.model).
+			Where(source.column+" <> ''").
+			Distinct().
+			Pluck(source.column, &names).Error
+		if err != nil {
+			return report, err
+		}
+
+		if _, ok := files[source.dir]; !ok {
+			dirs = append(dirs, source.dir)
+		}
+		files[source.dir] = append(files[source.dir], names...)
+	}
+
+	for _, dir := range dirs {
+		objects, err := storage.Default.List(dir + "/")
+		if err != nil {
+			return report, err
+		}
+		stored := map[string]bool{}
+		for _, object := range objects {
+			stored[path.Base(object.Key)] = true
+		}
+
+		seen := map[string]bool{}
+		for _, name := range files[dir] {
+			if seen[name] || !stored[name] || !IsImageFile(name) {
+				continue
+			}
+			seen[name] = true
+			report.Scanned++
+
+			if !force && !missingVariant(stored, name) {
+				continue
+			}
+
+			key := storage.Key(dir, name)
+			if err := backfillImage(dir, name); err != nil {
+				report.Failed[key] = err.Error()
+				continue
+			}
+			report.Processed = append(report.Processed, key)
+		}
+	}
+
+	return report, nil
+}
+
+func missingVariant(stored map[string]bool, fileName string) bool {
+	for _, variant := range ImageVariants() {
+		if !stored[VariantFileName(fileName, variant.Name)] {
+			return true
+		}
+		if WebPEnabled() && !stored[WebPFileName(fileName, variant.Name)] {
+			return true
+		}
+	}
+	return false
+}
+
+func backfillImage(dir, fileName string) error {
+	reader, err := storage.Default.Get(storage.Key(dir, fileName))
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return err
+	}
+
+	// older uploads were never checked against the pixel budget
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if config.Width*config.Height > MaxImagePixels() {
+		return fmt.Errorf("image is %dx%d, larger than UPLOAD_MAX_PIXELS", config.Width, config.Height)
+	}
+
+	return ProcessImage(dir, fileName, data)
+}
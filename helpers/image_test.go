@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+func encodeTestGIF(t *testing.T, frames, width, height int) []byte {
+	t.Helper()
+
+	palette := color.Palette{color.Black, color.White}
+	anim := &gif.GIF{}
+	for i := 0; i < frames; i++ {
+		anim.Image = append(anim.Image, image.NewPaletted(image.Rect(0, 0, width, height), palette))
+		anim.Delay = append(anim.Delay, 1)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, anim); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// bombGIF declares frames full size frames whose pixel data is a single
+// byte, it is tiny on disk but huge once every frame is decoded.
+func bombGIF(frames, width, height int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("GIF89a")
+	buf.Write([]byte{byte(width), byte(width >> 8), byte(height), byte(height >> 8), 0x80, 0, 0})
+	buf.Write([]byte{0, 0, 0, 255, 255, 255})
+	for i := 0; i < frames; i++ {
+		buf.Write([]byte{0x2c, 0, 0, 0, 0, byte(width), byte(width >> 8), byte(height), byte(height >> 8), 0})
+		buf.Write([]byte{0x02, 0x01, 0x44, 0x00})
+	}
+	buf.WriteByte(0x3b)
+	return buf.Bytes()
+}
+
+func TestReencodeImageGIF(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []byte
+		maxPixels int
+		tooLarge  bool
+	}{
+		{name: "small animation", data: encodeTestGIF(t, 3, 20, 20), maxPixels: 1000},
+		{name: "frames over the pixel budget", data: encodeTestGIF(t, 30, 20, 20), maxPixels: 1000, tooLarge: true},
+		{name: "frames over the frame limit", data: encodeTestGIF(t, maxGIFFrames+1, 1, 1), maxPixels: 1000, tooLarge: true},
+		// the frames can't even be decoded, so getting ErrImageTooLarge
+		// proves the check runs before DecodeAll
+		{name: "decompression bomb", data: bombGIF(5000, 4000, 4000), maxPixels: 40000000, tooLarge: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := ReencodeImage(tt.data, tt.maxPixels)
+			if tt.tooLarge {
+				if !errors.Is(err, ErrImageTooLarge) {
+					t.Fatalf("got %v, want ErrImageTooLarge", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			anim, err := gif.DecodeAll(bytes.NewReader(out))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(anim.Image) != 3 {
+				t.Fatalf("got %d frames, want 3", len(anim.Image))
+			}
+		})
+	}
+}
+
+func TestCheckGIFFramesMalformed(t *testing.T) {
+	data := encodeTestGIF(t, 1, 2, 2)
+	if err := checkGIFFrames(data[:len(data)-3], 1000); err == nil {
+		t.Fatal("expected an error for a truncated gif")
+	}
+}
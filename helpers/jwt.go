@@ -1,6 +1,11 @@
 package helpers
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
 	"time"
 
 	"github.com/ahmadalaik/desa-digital/config"
@@ -9,19 +14,96 @@ import (
 
 var jwtKey = []byte(config.GetEnv("JWT_SECRET", "sangatrahasiasekali"))
 
-func GenerateToken(username string) (string, error) {
-	expirationTime := time.Now().Add(60 * time.Minute)
+func AccessTokenTTL() time.Duration {
+	minutes, err := strconv.Atoi(config.GetEnv("JWT_ACCESS_TTL_MINUTES", "15"))
+	if err != nil || minutes < 1 {
+		minutes = 15
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+func RefreshTokenTTL() time.Duration {
+	hours, err := strconv.Atoi(config.GetEnv("JWT_REFRESH_TTL_HOURS", "168"))
+	if err != nil || hours < 1 {
+		hours = 168
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// GenerateAccessToken signs an access token bound to the given session, the
+// session UUID is carried in the jti claim so it can be revoked server-side.
+func GenerateAccessToken(username, sessionID string) (string, time.Time, error) {
+	expirationTime := time.Now().Add(AccessTokenTTL())
 
 	claims := &jwt.RegisteredClaims{
+		ID:        sessionID,
 		Subject:   username,
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
 		ExpiresAt: jwt.NewNumericDate(expirationTime),
 	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenStr, err := token.SignedString(jwtKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenStr, expirationTime, nil
+}
+
+// GenerateRefreshToken returns an opaque random token and the hash that is
+// stored in the database. Only the hash is ever persisted.
+func GenerateRefreshToken() (token string, hash string, err error) {
+	return GenerateOpaqueToken()
+}
+
+// GenerateOpaqueToken returns a random URL safe token, such as a password
+// reset token, and its HashToken hash.
+func GenerateOpaqueToken() (token string, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token = base64.RawURLEncoding.EncodeToString(b)
+	return token, HashToken(token), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+const twoFactorAudience = "two-factor"
+
+// GenerateTwoFactorChallenge signs a short-lived token proving the password
+// step succeeded. It has no session so AuthMiddleware never accepts it.
+func GenerateTwoFactorChallenge(username string) (string, time.Time, error) {
+	expirationTime := time.Now().Add(5 * time.Minute)
+
+	claims := &jwt.RegisteredClaims{
+		Subject:   username,
+		Audience:  jwt.ClaimStrings{twoFactorAudience},
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		ExpiresAt: jwt.NewNumericDate(expirationTime),
+	}
+
+	tokenStr, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtKey)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenStr, expirationTime, nil
+}
+
+func ParseTwoFactorChallenge(tokenStr string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+
+	_, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
+		return jwtKey, nil
+	}, jwt.WithAudience(twoFactorAudience), jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
 	if err != nil {
 		return "", err
 	}
 
-	return tokenStr, err
+	return claims.Subject, nil
 }
@@ -0,0 +1,97 @@
+package helpers
+
+import (
+	"fmt"
+	"log"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/google/uuid"
+)
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m SMTPMailer) Send(msg MailMessage) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, []string{msg.To}, buildMailBody(m.From, msg))
+}
+
+// LogMailer writes every message as an .eml file into Dir, or to the
+// application log when Dir is empty. Useful for tests and offline installs.
+type LogMailer struct {
+	Dir  string
+	From string
+}
+
+func (m LogMailer) Send(msg MailMessage) error {
+	body := buildMailBody(m.From, msg)
+
+	if m.Dir == "" {
+		log.Printf("mail to %s:\n%s", msg.To, body)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.Dir, 0755); err != nil {
+		return err
+	}
+
+	fileName := fmt.Sprintf("%s_%s.eml", time.Now().Format("20060102150405"), uuid.New().String())
+	return os.WriteFile(filepath.Join(m.Dir, fileName), body, 0644)
+}
+
+func NewMailer() Mailer {
+	from := config.GetEnv("MAIL_FROM", "no-reply@desa-digital.local")
+
+	switch config.GetEnv("MAIL_DRIVER", "log") {
+	case "smtp":
+		return SMTPMailer{
+			Host:     config.GetEnv("MAIL_HOST", "localhost"),
+			Port:     config.GetEnv("MAIL_PORT", "587"),
+			Username: config.GetEnv("MAIL_USERNAME", ""),
+			Password: config.GetEnv("MAIL_PASSWORD", ""),
+			From:     from,
+		}
+	default:
+		return LogMailer{
+			Dir:  config.GetEnv("MAIL_LOG_DIR", ""),
+			From: from,
+		}
+	}
+}
+
+func buildMailBody(from string, msg MailMessage) []byte {
+	headers := []string{
+		"From: " + from,
+		"To: " + msg.To,
+		"Subject: " + msg.Subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+
+	return []byte(strings.Join(headers, "\r\n") + "\r\n\r\n" + msg.Body)
+}
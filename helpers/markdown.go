@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"bytes"
+	"log"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// markdown renders CommonMark with pipe tables, footnotes, strikethrough
+// and bare URL autolinks. Raw HTML is not passed through, goldmark leaves
+// an HTML comment in its place. Column alignment is an align attribute
+// since the sanitiser drops style.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.Footnote,
+	),
+)
+
+// RenderMarkdown converts Markdown to HTML. The output still goes through
+// the sanitiser before it is stored.
+func RenderMarkdown(source string) string {
+	var b bytes.Buffer
+	if err := markdown.Convert([]byte(source), &b); err != nil {
+		log.Println("failed to render markdown:", err)
+	}
+	return b.String()
+}
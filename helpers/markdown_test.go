@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/ahmadalaik/desa-digital/models"
+)
+
+// The cases go through RenderContent, so they cover what is stored: the
+// rendered Markdown after the post sanitiser.
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "table with alignment and escaped pipe",
+			input: "| Name | Qty |\n|:-----|----:|\n| Beras | 10 |\n| Gula \\| Pasir | *2* |",
+			want: "<table>\n<thead>\n<tr>\n" +
+				`<th align="left">Name</th>` + "\n" + `<th align="right">Qty</th>` + "\n" +
+				"</tr>\n</thead>\n<tbody>\n<tr>\n" +
+				`<td align="left">Beras</td>` + "\n" + `<td align="right">10</td>` + "\n" +
+				"</tr>\n<tr>\n" +
+				`<td align="left">Gula | Pasir</td>` + "\n" + `<td align="right"><em>2</em></td>` + "\n" +
+				"</tr>\n</tbody>\n</table>\n",
+		},
+		{
+			name:  "footnote",
+			input: "Dana desa[^1] naik.\n\n[^1]: Lihat **APBDes** 2024.",
+			want: `<p>Dana desa<sup id="fnref:1"><a href="#fn:1">1</a></sup> naik.</p>` + "\n" +
+				`<div class="footnotes">` + "\n<hr>\n<ol>\n" +
+				`<li id="fn:1">` + "\n" +
+				"<p>Lihat <strong>APBDes</strong> 2024.\u00a0" + `<a href="#fnref:1">↩︎</a></p>` + "\n" +
+				"</li>\n</ol>\n</div>\n",
+		},
+		{
+			name:  "bare autolinks leave trailing punctuation out",
+			input: "Lihat https://desa.id/berita?id=1. atau www.example.com, (https://a.id/x_(y))",
+			want: `<p>Lihat <a href="https://desa.id/berita?id=1">https://desa.id/berita?id=1</a>. ` +
+				`atau <a href="http://www.example.com">www.example.com</a>, ` +
+				`(<a href="https://a.id/x_(y)">https://a.id/x_(y)</a>)</p>` + "\n",
+		},
+		{
+			name:  "links, images and emphasis",
+			input: `[tautan](https://x.id "judul") ![alt](/a.png) ` + "`code` ~~del~~ **bold** _em_",
+			want: `<p><a href="https://x.id" title="judul">tautan</a> <img src="/a.png" alt="alt"> ` +
+				"<code>code</code> <del>del</del> <strong>bold</strong> <em>em</em></p>\n",
+		},
+		{
+			name:  "javascript links dropped",
+			input: "[x](javascript:alert(1))",
+			want:  "<p><a>x</a></p>\n",
+		},
+		{
+			name:  "raw html left out",
+			input: "a <b onclick=\"x()\">b</b>\n\n<script>alert(1)</script>",
+			want:  "<p>a b</p>\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, got := RenderContent(ContentTypePost, models.ContentFormatMarkdown, tt.input); got != tt.want {
+				t.Errorf("RenderContent(%q) =\n%q\nwant\n%q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
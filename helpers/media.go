@@ -0,0 +1,142 @@
+package helpers
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+const MediaDirectory = "media"
+
+var MediaAllowedTypes = []string{".jpg", ".jpeg", ".png", ".gif", ".pdf", ".mp4", ".webm"}
+
+var ErrMediaNotImage = errors.New("media is not an image")
+
+// MediaMaxSize limits files added to the library, videos and documents are
+// meant to come in through a resumable upload.
+func MediaMaxSize() int64 {
+	size, err := strconv.ParseInt(config.GetEnv("MEDIA_MAX_SIZE", strconv.Itoa(200<<20)), 10, 64)
+	if err != nil || size <= 0 {
+		return 200 << 20
+	}
+	return size
+}
+
+// embeddedMediaPattern finds media files linked from rich content, variants
+// included, by the uuid their names start with.
+var embeddedMediaPattern = regexp.MustCompile(`/` + MediaDirectory + `/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})`)
+
+func MediaTypeFor(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return models.MediaTypeImage
+	case strings.HasPrefix(contentType, "video/"):
+		return models.MediaTypeVideo
+	default:
+		return models.MediaTypeDocument
+	}
+}
+
+// FindImageMedia loads the media an entity wants to use as its image.
+func FindImageMedia(id uint) (*models.Media, error) {
+	var media models.Media
+	if err := database.DB.First(&media, id).Error; err != nil {
+		return nil, err
+	}
+	if media.Type != models.MediaTypeImage {
+		return nil, ErrMediaNotImage
+	}
+	return &media, nil
+}
+
+// EntityImages prefers the entity's own upload and falls back to the media
+// it references.
+func EntityImages(dir, image string, media *models.Media) *structs.ImagesResponse {
+	if image == "" && media != nil {
+		return ImageURLs(MediaDirectory, media.FileName)
+	}
+	return ImageURLs(dir, image)
+}
+
+// EntityImageURL is the URL of the entity's own upload or of the media it
+// references, empty when it has neither.
+func EntityImageURL(dir, image string, media *models.Media) string {
+	if images := EntityImages(dir, image, media); images != nil {
+		return images.Original
+	}
+	return ""
+}
+
+func MediaSimpleResponse(media *models.Media) *structs.MediaSimpleResponse {
+	if media == nil || media.ID == 0 {
+		return nil
+	}
+	return &structs.MediaSimpleResponse{
+		ID:      media.ID,
+		URL:     UploadURL(SiteURL(), MediaDirectory, media.FileName),
+		AltText: media.AltText,
+	}
+}
+
+// SyncMediaUsage replaces the usages recorded for an entity with the media
+// it references by ID and the media embedded in its content.
+func SyncMediaUsage(entityType string, entityID uint, mediaID *uint, content string) {
+	ids := []uint{}
+	if mediaID != nil && *mediaID != 0 {
+		ids = append(ids, *mediaID)
+	}
+
+	for _, match := range embeddedMediaPattern.FindAllStringSubmatch(content, -1) {
+		var media models.Media
+		if err := database.DB.Select("id").Where("file_name LIKE ?", match[1]+".%").First(&media).Error; err == nil {
+			ids = append(ids, media.ID)
+		}
+	}
+
+	err := database.DB.Where("entity_type = ? AND entity_id = ?", entityType, entityID).Delete(&models.MediaUsage{}).Error
+	if err != nil {
+		log.Println("failed to sync media usage:", err)
+		return
+	}
+
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		usage := models.MediaUsage{MediaID: id, EntityType: entityType, EntityID: entityID}
+		if err := database.DB.Create(&usage).Error; err != nil {
+			log.Println("failed to sync media usage:", err)
+		}
+	}
+}
+
+// ResolveImageMedia checks the media_id sent in place of an image upload.
+// It returns nil when no media was referenced.
+func ResolveImageMedia(mediaID *uint) (*uint, *structs.ErrorResponse) {
+	if mediaID == nil || *mediaID == 0 {
+		return nil, nil
+	}
+
+	if _, err := FindImageMedia(*mediaID); err != nil {
+		message := "Media not found"
+		if errors.Is(err, ErrMediaNotImage) {
+			message = err.Error()
+		}
+		return nil, &structs.ErrorResponse{
+			Success: false,
+			Message: "Validation Errors",
+			Errors:  map[string]string{"MediaID": message},
+		}
+	}
+	return mediaID, nil
+}
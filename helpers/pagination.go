@@ -97,29 +97,35 @@ func BuildPaginationLinks(currentPage, lastPage int, baseURL, search string) []P
 }
 
 func PaginateResponse(c *gin.Context, data any, total int64, page, limit int, baseURL, search, message string) {
+	c.JSON(http.StatusOK, structs.SuccessResponse{
+		Success: true,
+		Message: message,
+		Data:    PaginationData(data, total, page, limit, baseURL, search),
+	})
+}
+
+// PaginationData is the paginated payload PaginateResponse sends, for
+// responses that nest a page of items inside another object.
+func PaginationData(data any, total int64, page, limit int, baseURL, search string) gin.H {
 	lastPage := TotalPage(total, limit)
 	from := (page-1)*limit + 1
 	to := from + reflect.ValueOf(data).Len() - 1
 
 	links := BuildPaginationLinks(page, lastPage, baseURL, search)
 
-	c.JSON(http.StatusOK, structs.SuccessResponse{
-		Success: true,
-		Message: message,
-		Data: gin.H{
-			"current_page":   page,
-			"data":           data,
-			"first_page_url": fmt.Sprintf("%s?page=1%s", baseURL, QueryString(search)),
-			"from":           from,
-			"last_page":      lastPage,
-			"last_page_url":  fmt.Sprintf("%s?page=%s%s", baseURL, strconv.Itoa(lastPage), QueryString(search)),
-			"links":          links,
-			"next_page_url":  PageURL(baseURL, page+1, lastPage, search),
-			"path":           baseURL,
-			"per_page":       limit,
-			"prev_page_url":  PageURL(baseURL, page-1, lastPage, search),
-			"to":             to,
-			"total":          total,
-		},
-	})
+	return gin.H{
+		"current_page":   page,
+		"data":           data,
+		"first_page_url": fmt.Sprintf("%s?page=1%s", baseURL, QueryString(search)),
+		"from":           from,
+		"last_page":      lastPage,
+		"last_page_url":  fmt.Sprintf("%s?page=%s%s", baseURL, strconv.Itoa(lastPage), QueryString(search)),
+		"links":          links,
+		"next_page_url":  PageURL(baseURL, page+1, lastPage, search),
+		"path":           baseURL,
+		"per_page":       limit,
+		"prev_page_url":  PageURL(baseURL, page-1, lastPage, search),
+		"to":             to,
+		"total":          total,
+	}
 }
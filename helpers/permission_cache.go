@@ -0,0 +1,87 @@
+package helpers
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+)
+
+type UserPermissions struct {
+	Permissions map[string]bool
+	// TwoFactorPending is true when the 2FA policy applies to the user but
+	// they haven't enrolled yet.
+	TwoFactorPending bool
+}
+
+type permissionCacheEntry struct {
+	value     UserPermissions
+	expiresAt time.Time
+}
+
+var permissionCache = struct {
+	sync.RWMutex
+	entries map[string]permissionCacheEntry
+}{entries: map[string]permissionCacheEntry{}}
+
+func permissionCacheTTL() time.Duration {
+	seconds, err := strconv.Atoi(config.GetEnv("PERMISSION_CACHE_TTL_SECONDS", "300"))
+	if err != nil || seconds < 0 {
+		seconds = 300
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// ResolvePermissions returns the permission set for a username, hitting the
+// database only when the cached entry is missing or expired.
+func ResolvePermissions(username string) (UserPermissions, error) {
+	permissionCache.RLock()
+	entry, ok := permissionCache.entries[username]
+	permissionCache.RUnlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.value, nil
+	}
+
+	var user models.User
+	err := database.DB.
+		Preload("Roles.Permissions").
+		Where("username = ?", username).
+		First(&user).Error
+	if err != nil {
+		return UserPermissions{}, err
+	}
+
+	permissions := GetPermission(user.Roles)
+	value := UserPermissions{
+		Permissions:      permissions,
+		TwoFactorPending: !user.TwoFactorEnabled && RequiresTwoFactor(permissions) && TwoFactorPolicyEnabled(),
+	}
+
+	if ttl := permissionCacheTTL(); ttl > 0 {
+		permissionCache.Lock()
+		permissionCache.entries[username] = permissionCacheEntry{value: value, expiresAt: time.Now().Add(ttl)}
+		permissionCache.Unlock()
+	}
+
+	return value, nil
+}
+
+func InvalidatePermissions(usernames ...string) {
+	permissionCache.Lock()
+	for _, username := range usernames {
+		delete(permissionCache.entries, username)
+	}
+	permissionCache.Unlock()
+}
+
+// FlushPermissionCache drops every entry, used when a role or permission
+// changes and any number of users may be affected.
+func FlushPermissionCache() {
+	permissionCache.Lock()
+	permissionCache.entries = map[string]permissionCacheEntry{}
+	permissionCache.Unlock()
+}
@@ -0,0 +1,189 @@
+package helpers
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/storage"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+const (
+	ReconcileModeDryRun     = "dry-run"
+	ReconcileModeDelete     = "delete"
+	ReconcileModeQuarantine = "quarantine"
+)
+
+// QuarantineDir is where orphans are moved by a reconcile run, from
+// RECONCILE_QUARANTINE_DIR. It is a local directory kept apart from the
+// storage root, which is served publicly.
+func QuarantineDir() string {
+	return config.GetEnv("RECONCILE_QUARANTINE_DIR", "storage/quarantine")
+}
+
+// reconcileGracePeriod leaves fresh files alone, a request may have stored
+// the upload and not yet saved the row pointing to it.
+const reconcileGracePeriod = time.Hour
+
+// reconcileSources lists every column that holds the name of an uploaded
+// file. Files only kept for history are never reported missing.
+var reconcileSources = []struct {
+	dir        string
+	entityType string
+	model      any
+	column     string
+	where      map[string]any
+	history    bool
+}{
+	{dir: "posts", entityType: "post", model: &models.Post{}, column: "image"},
+	{dir: "posts", entityType: "revision", model: &models.Revision{}, column: "image", where: map[string]any{"entity_type": RevisionEntityPost}, history: true},
+	{dir: "products", entityType: "product", model: &models.Product{}, column: "image"},
+	{dir: "photos", entityType: "photo", model: &models.Photo{}, column: "image"},
+	{dir: "sliders", entityType: "slider", model: &models.Slider{}, column: "image"},
+	{dir: "aparaturs", entityType: "aparatur", model: &models.Aparatur{}, column: "image"},
+	{dir: "albums", entityType: "album", model: &models.Album{}, column: "image"},
+	{dir: MediaDirectory, entityType: "media", model: &models.Media{}, column: "file_name"},
+}
+
+type uploadReference struct {
+	EntityID uint
+	FileName string
+}
+
+// ReconcileUploads compares the upload directories with the database. Files
+// no row points to are orphans, with their image variants, and are deleted
+// or moved under QuarantineDir depending on mode. Rows whose file is
+// gone are reported as missing.
+func ReconcileUploads(mode string) (structs.ReconcileResponse, error) {
+	if mode == "" {
+		mode = ReconcileModeDryRun
+	}
+
+	report := structs.ReconcileResponse{
+		Mode:    mode,
+		Orphans: []structs.ReconcileFileResponse{},
+		Missing: []structs.ReconcileMissingResponse{},
+	}
+
+	names := map[string]map[string]bool{}
+	bases := map[string]map[string]bool{}
+	references := make([][]uploadReference, len(reconcileSources))
+	var dirs []string
+
+	for i, source := range reconcileSources {
+		if names[source.dir] == nil {
+			names[source.dir], bases[source.dir] = map[string]bool{}, map[string]bool{}
+			dirs = append(dirs, source.dir)
+		}
+
+		// trashed rows still own their files until they are purged
+		query := database.DB.Unscoped().Model(source.model).
+			Select("id AS entity_id, " + source.column + " AS file_name").
+			Where(source.column + " <> ''")
+		if source.where != nil {
+			query = query.Where(source.where)
+		}
+		if err := query.Scan(&references[i]).Error; err != nil {
+			return report, err
+		}
+
+		for _, reference := range references[i] {
+			names[source.dir][reference.FileName] = true
+			bases[source.dir][strings.TrimSuffix(reference.FileName, filepath.Ext(reference.FileName))] = true
+		}
+	}
+
+	quarantine := storage.NewLocal(filepath.Join(QuarantineDir(), time.Now().Format("20060102150405")), "")
+
+	for _, dir := range dirs {
+		objects, err := storage.Default.List(dir + "/")
+		if err != nil {
+			return report, err
+		}
+
+		stored := map[string]bool{}
+		for _, object := range objects {
+			report.Scanned++
+			name := path.Base(object.Key)
+			stored[name] = true
+
+			if isReferencedUpload(names[dir], bases[dir], name) || time.Since(object.ModTime) < reconcileGracePeriod {
+				continue
+			}
+
+			orphan := structs.ReconcileFileResponse{
+				Key:     object.Key,
+				Size:    object.Size,
+				ModTime: object.ModTime.Format("2006-01-02 15:04:05"),
+				Action:  "kept",
+			}
+			switch mode {
+			case ReconcileModeDelete:
+				if err := storage.Default.Delete(object.Key); err != nil {
+					orphan.Error = err.Error()
+				} else {
+					orphan.Action = "deleted"
+				}
+			case ReconcileModeQuarantine:
+				if err := moveObject(object, quarantine); err != nil {
+					orphan.Error = err.Error()
+				} else {
+					orphan.Action = filepath.Join(quarantine.Root, filepath.FromSlash(object.Key))
+				}
+			}
+
+			report.Orphans = append(report.Orphans, orphan)
+			report.OrphanBytes += object.Size
+		}
+
+		for i, source := range reconcileSources {
+			if source.dir != dir || source.history {
+				continue
+			}
+			for _, reference := range references[i] {
+				if stored[reference.FileName] {
+					continue
+				}
+				report.Missing = append(report.Missing, structs.ReconcileMissingResponse{
+					Key:        storage.Key(dir, reference.FileName),
+					EntityType: source.entityType,
+					EntityID:   reference.EntityID,
+				})
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// isReferencedUpload also accepts the variants of a referenced file, named
+// "<base>-<variant>.<ext>".
+func isReferencedUpload(names, bases map[string]bool, name string) bool {
+	if names[name] {
+		return true
+	}
+
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	if i := strings.LastIndex(stem, "-"); i > 0 {
+		return bases[stem[:i]]
+	}
+	return false
+}
+
+func moveObject(object storage.Object, target storage.Storage) error {
+	src, err := storage.Default.Get(object.Key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := target.Put(object.Key, src, object.Size, UploadContentType(object.Key)); err != nil {
+		return err
+	}
+	return storage.Default.Delete(object.Key)
+}
@@ -0,0 +1,135 @@
+package helpers
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RevisionEntityPost = "post"
+	RevisionEntityPage = "page"
+)
+
+func SavePostRevision(c *gin.Context, post models.Post) {
+	revision := postRevision(post)
+	revision.UserID, _ = AuthUserID(c)
+	saveRevision(revision)
+}
+
+// EnsurePostRevision snapshots a post that has no revision yet, one saved
+// before revisions existed, so its current image stays referenced and is
+// only removed once that revision is pruned.
+func EnsurePostRevision(post models.Post) {
+	var count int64
+	database.DB.Model(&models.Revision{}).
+		Where("entity_type = ? AND entity_id = ?", RevisionEntityPost, post.ID).
+		Count(&count)
+	if count > 0 {
+		return
+	}
+
+	revision := postRevision(post)
+	revision.UserID = post.UserID
+	revision.CreatedAt = post.UpdatedAt
+	saveRevision(revision)
+}
+
+func postRevision(post models.Post) models.Revision {
+	categoryID := post.CategoryID
+
+	return models.Revision{
+		EntityType:    RevisionEntityPost,
+		EntityID:      post.ID,
+		Title:         post.Title,
+		Content:       post.Content,
+		ContentFormat: post.ContentFormat,
+		Image:         post.Image,
+		MediaID:       post.MediaID,
+		CategoryID:    &categoryID,
+	}
+}
+
+func SavePageRevision(c *gin.Context, page models.Page) {
+	revision := models.Revision{
+		EntityType:    RevisionEntityPage,
+		EntityID:      page.ID,
+		Title:         page.Title,
+		Content:       page.Content,
+		ContentFormat: page.ContentFormat,
+	}
+	revision.UserID, _ = AuthUserID(c)
+	saveRevision(revision)
+}
+
+func saveRevision(revision models.Revision) {
+	if err := database.DB.Create(&revision).Error; err != nil {
+		log.Println("failed to save revision:", err)
+		return
+	}
+
+	pruneRevisions(revision.EntityType, revision.EntityID)
+}
+
+// RevisionLimit is how many revisions are kept per post or page, from
+// REVISION_LIMIT. Zero keeps them all.
+func RevisionLimit() int {
+	limit, err := strconv.Atoi(config.GetEnv("REVISION_LIMIT", "50"))
+	if err != nil || limit < 0 {
+		limit = 50
+	}
+	return limit
+}
+
+// pruneRevisions drops the oldest revisions past RevisionLimit. Post images
+// only those revisions pointed at are removed too, the newest revision
+// always mirrors the live post so its image is never touched.
+func pruneRevisions(entityType string, entityID uint) {
+	limit := RevisionLimit()
+	if limit == 0 {
+		return
+	}
+
+	var pruned []models.Revision
+	err := database.DB.Where("entity_type = ? AND entity_id = ?", entityType, entityID).
+		Order("id DESC").
+		Offset(limit).
+		Find(&pruned).Error
+	if err != nil || len(pruned) == 0 {
+		return
+	}
+
+	ids := []uint{}
+	images := map[string]bool{}
+	for _, revision := range pruned {
+		ids = append(ids, revision.ID)
+		if revision.Image != "" {
+			images[revision.Image] = true
+		}
+	}
+
+	if err := database.DB.Delete(&models.Revision{}, ids).Error; err != nil {
+		log.Println("failed to prune revisions:", err)
+		return
+	}
+
+	if entityType != RevisionEntityPost {
+		return
+	}
+	for image := range images {
+		var references int64
+		database.DB.Model(&models.Revision{}).
+			Where("entity_type = ? AND entity_id = ? AND image = ?", entityType, entityID, image).
+			Count(&references)
+		if references > 0 {
+			continue
+		}
+		if err := RemoveUpload("posts", image); err != nil {
+			log.Printf("failed to remove revision image %s: %v", image, err)
+		}
+	}
+}
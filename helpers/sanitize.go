@@ -0,0 +1,213 @@
+package helpers
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	ContentTypePost    = "post"
+	ContentTypePage    = "page"
+	ContentTypeProduct = "product"
+)
+
+// SanitizePolicy is an allowlist: elements missing from Elements are
+// unwrapped (their text is kept), attributes missing from the element's
+// list are dropped.
+type SanitizePolicy struct {
+	Elements   map[string][]string
+	URLSchemes []string
+}
+
+var (
+	// SanitizePolicyRich fits the WYSIWYG editor and rendered Markdown used
+	// for posts and pages.
+	SanitizePolicyRich = SanitizePolicy{
+		Elements: map[string][]string{
+			"p": {"class"}, "br": nil, "hr": nil, "span": {"class"}, "div": {"class"},
+			"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil,
+			"strong": nil, "b": nil, "em": nil, "i": nil, "u": nil, "s": nil, "del": nil, "sub": nil, "sup": {"id"}, "mark": nil,
+			"blockquote": nil, "pre": nil, "code": {"class"},
+			"ul": nil, "ol": {"start"}, "li": {"id"},
+			"a":      {"href", "title", "target", "id"},
+			"img":    {"src", "alt", "title", "width", "height"},
+			"figure": nil, "figcaption": nil,
+			"table": nil, "thead": nil, "tbody": nil, "tfoot": nil, "tr": nil,
+			"th": {"colspan", "rowspan", "align"}, "td": {"colspan", "rowspan", "align"},
+			"section": {"class", "id"},
+		},
+		URLSchemes: []string{"http", "https", "mailto", "tel"},
+	}
+
+	// SanitizePolicyBasic keeps simple formatting only, for short
+	// descriptions such as products.
+	SanitizePolicyBasic = SanitizePolicy{
+		Elements: map[string][]string{
+			"p": nil, "br": nil, "strong": nil, "b": nil, "em": nil, "i": nil, "u": nil,
+			"ul": nil, "ol": nil, "li": nil,
+			"a": {"href", "title"},
+		},
+		URLSchemes: []string{"http", "https", "mailto", "tel"},
+	}
+
+	// SanitizePolicyStrict strips every tag and leaves plain text.
+	SanitizePolicyStrict = SanitizePolicy{}
+)
+
+var sanitizePolicies = map[string]SanitizePolicy{
+	"rich":   SanitizePolicyRich,
+	"basic":  SanitizePolicyBasic,
+	"strict": SanitizePolicyStrict,
+}
+
+var defaultSanitizePolicies = map[string]string{
+	ContentTypePost:    "rich",
+	ContentTypePage:    "rich",
+	ContentTypeProduct: "basic",
+}
+
+// dropWithContent are elements whose content must never reach the output,
+// not even as text.
+var dropWithContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true,
+	"object": true, "embed": true, "applet": true, "noscript": true, "template": true,
+	"svg": true, "math": true, "textarea": true, "select": true, "title": true,
+	"head": true, "meta": true, "link": true, "base": true, "form": true,
+}
+
+var urlAttributes = map[string]bool{"href": true, "src": true}
+
+// SanitizePolicyFor returns the policy of a content type, which can be
+// switched with SANITIZE_POLICY_<TYPE>=rich|basic|strict.
+func SanitizePolicyFor(contentType string) SanitizePolicy {
+	name := config.GetEnv("SANITIZE_POLICY_"+strings.ToUpper(contentType), defaultSanitizePolicies[contentType])
+	if policy, ok := sanitizePolicies[name]; ok {
+		return policy
+	}
+	return SanitizePolicyStrict
+}
+
+func SanitizeContent(contentType, content string) string {
+	return SanitizeHTML(content, SanitizePolicyFor(contentType))
+}
+
+func SanitizeHTML(content string, policy SanitizePolicy) string {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(strings.NewReader(content), context)
+	if err != nil {
+		return html.EscapeString(content)
+	}
+
+	var b strings.Builder
+	for _, node := range nodes {
+		sanitizeNode(&b, node, policy)
+	}
+	return b.String()
+}
+
+func sanitizeNode(b *strings.Builder, node *html.Node, policy SanitizePolicy) {
+	switch node.Type {
+	case html.TextNode:
+		b.WriteString(html.EscapeString(node.Data))
+		return
+	case html.ElementNode:
+	default:
+		// comments, doctypes and the like are dropped
+		return
+	}
+
+	tag := strings.ToLower(node.Data)
+	if dropWithContent[tag] {
+		return
+	}
+
+	allowedAttrs, allowed := policy.Elements[tag]
+	if !allowed {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			sanitizeNode(b, child, policy)
+		}
+		return
+	}
+
+	b.WriteString("<" + tag)
+	blankTarget := false
+	for _, attr := range node.Attr {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !slices.Contains(allowedAttrs, key) {
+			continue
+		}
+
+		value := attr.Val
+		if urlAttributes[key] {
+			var ok bool
+			if value, ok = sanitizeURL(value, policy.URLSchemes); !ok {
+				continue
+			}
+		}
+		if key == "target" {
+			if value != "_blank" {
+				continue
+			}
+			blankTarget = true
+		}
+
+		b.WriteString(" " + key + `="` + html.EscapeString(value) + `"`)
+	}
+	if blankTarget {
+		b.WriteString(` rel="noopener noreferrer"`)
+	}
+	b.WriteString(">")
+
+	if isVoidElement(tag) {
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		sanitizeNode(b, child, policy)
+	}
+	b.WriteString("</" + tag + ">")
+}
+
+// sanitizeURL accepts relative URLs and absolute ones using an allowed
+// scheme. Control characters and whitespace are removed first since
+// browsers ignore them, e.g. "java\tscript:".
+func sanitizeURL(raw string, schemes []string) (string, bool) {
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, raw)
+	if cleaned == "" {
+		return "", false
+	}
+
+	u, err := url.Parse(cleaned)
+	if err != nil {
+		return "", false
+	}
+	if u.Scheme == "" {
+		// a leading colon before any slash would be read as a scheme
+		if i := strings.IndexAny(cleaned, ":/?#"); i >= 0 && cleaned[i] == ':' {
+			return "", false
+		}
+		return cleaned, true
+	}
+	if !slices.Contains(schemes, strings.ToLower(u.Scheme)) {
+		return "", false
+	}
+	return cleaned, true
+}
+
+func isVoidElement(tag string) bool {
+	switch tag {
+	case "br", "hr", "img", "wbr", "col", "area", "source", "track", "input":
+		return true
+	}
+	return false
+}
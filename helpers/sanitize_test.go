@@ -0,0 +1,136 @@
+package helpers
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SanitizePolicy
+		input  string
+		want   string
+	}{
+		{
+			name:   "script dropped with its content",
+			policy: SanitizePolicyRich,
+			input:  `<p>hi</p><script>alert(1)</script>`,
+			want:   `<p>hi</p>`,
+		},
+		{
+			name:   "iframe dropped with its content",
+			policy: SanitizePolicyRich,
+			input:  `<iframe src="https://evil.example"><p>inside</p></iframe><p>after</p>`,
+			want:   `<p>after</p>`,
+		},
+		{
+			name:   "script nested in svg",
+			policy: SanitizePolicyRich,
+			input:  `<svg><script>alert(1)</script><text>x</text></svg>ok`,
+			want:   `ok`,
+		},
+		{
+			name:   "onerror removed",
+			policy: SanitizePolicyRich,
+			input:  `<img src="/a.png" onerror="alert(1)">`,
+			want:   `<img src="/a.png">`,
+		},
+		{
+			name:   "onclick removed",
+			policy: SanitizePolicyRich,
+			input:  `<p onclick="alert(1)" class="lead">x</p>`,
+			want:   `<p class="lead">x</p>`,
+		},
+		{
+			name:   "javascript href dropped",
+			policy: SanitizePolicyRich,
+			input:  `<a href="javascript:alert(1)">x</a>`,
+			want:   `<a>x</a>`,
+		},
+		{
+			name:   "javascript with a tab in the scheme dropped",
+			policy: SanitizePolicyRich,
+			input:  "<a href=\"java\tscript:alert(1)\">x</a>",
+			want:   `<a>x</a>`,
+		},
+		{
+			name:   "javascript src dropped",
+			policy: SanitizePolicyRich,
+			input:  `<img src="JavaScript:alert(1)" alt="a">`,
+			want:   `<img alt="a">`,
+		},
+		{
+			name:   "blank target gets rel",
+			policy: SanitizePolicyRich,
+			input:  `<a href="https://example.com" target="_blank">x</a>`,
+			want:   `<a href="https://example.com" target="_blank" rel="noopener noreferrer">x</a>`,
+		},
+		{
+			name:   "other targets dropped",
+			policy: SanitizePolicyRich,
+			input:  `<a href="/x" target="_top">x</a>`,
+			want:   `<a href="/x">x</a>`,
+		},
+		{
+			name:   "rich keeps tables and headings",
+			policy: SanitizePolicyRich,
+			input:  `<h2>T</h2><table><tbody><tr><td colspan="2" style="color:red">1</td></tr></tbody></table>`,
+			want:   `<h2>T</h2><table><tbody><tr><td colspan="2">1</td></tr></tbody></table>`,
+		},
+		{
+			name:   "basic unwraps headings and images",
+			policy: SanitizePolicyBasic,
+			input:  `<h1>Title</h1><p><strong>bold</strong> <img src="/a.png"><a href="/x" target="_blank">link</a></p>`,
+			want:   `Title<p><strong>bold</strong> <a href="/x">link</a></p>`,
+		},
+		{
+			name:   "strict leaves plain text",
+			policy: SanitizePolicyStrict,
+			input:  `<p>a <b>b</b> &amp; <script>c</script></p>`,
+			want:   `a b &amp; `,
+		},
+		{
+			name:   "comments dropped",
+			policy: SanitizePolicyRich,
+			input:  `<p>a<!-- <script>x</script> --></p>`,
+			want:   `<p>a</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeHTML(tt.input, tt.policy); got != tt.want {
+				t.Errorf("SanitizeHTML(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeURL(t *testing.T) {
+	schemes := []string{"http", "https", "mailto", "tel"}
+
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{"https://example.com/a?b=c", "https://example.com/a?b=c", true},
+		{"/static/posts/a.jpg", "/static/posts/a.jpg", true},
+		{"#section", "#section", true},
+		{"mailto:desa@example.com", "mailto:desa@example.com", true},
+		{"javascript:alert(1)", "", false},
+		{"JAVASCRIPT:alert(1)", "", false},
+		{"java\tscript:alert(1)", "", false},
+		{" \njavascript:alert(1)", "", false},
+		{"java\x00script:alert(1)", "", false},
+		{"data:text/html;base64,PHNjcmlwdD4=", "", false},
+		{"vbscript:msgbox(1)", "", false},
+		{":alert(1)", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := sanitizeURL(tt.input, schemes)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("sanitizeURL(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/models"
+	"gorm.io/gorm"
+)
+
+// PublishedPosts limits a query to posts visible on the public site.
+// Posts published with a future date stay hidden until that moment.
+func PublishedPosts(db *gorm.DB) *gorm.DB {
+	return db.Where("posts.status = ? AND posts.published_at <= ?", models.PostStatusPublished, time.Now())
+}
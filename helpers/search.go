@@ -0,0 +1,121 @@
+package helpers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+const (
+	SearchTypePost     = "post"
+	SearchTypePage     = "page"
+	SearchTypeProduct  = "product"
+	SearchTypePhoto    = "photo"
+	SearchTypeAparatur = "aparatur"
+)
+
+// searchSources maps a result type to the select producing it. Each select
+// exposes the same columns so they can be combined with UNION ALL; the
+// query is bound to the "q" CTE.
+var searchSources = map[string]string{
+	SearchTypePost: `SELECT 'post' AS type, posts.id, posts.title, posts.slug, posts.image,
+		ts_rank(posts.search_vector, q.query) AS rank,
+		ts_headline('desa_search', regexp_replace(posts.content_html, '<[^>]*>', ' ', 'g'), q.query, @headline) AS snippet,
+		posts.updated_at
+		FROM posts, q WHERE posts.search_vector @@ q.query AND posts.deleted_at IS NULL AND posts.status = @status AND posts.published_at <= @now`,
+	SearchTypePage: `SELECT 'page' AS type, pages.id, pages.title, pages.slug, '' AS image,
+		ts_rank(pages.search_vector, q.query) AS rank,
+		ts_headline('desa_search', regexp_replace(pages.content_html, '<[^>]*>', ' ', 'g'), q.query, @headline) AS snippet,
+		pages.updated_at
+		FROM pages, q WHERE pages.search_vector @@ q.query AND pages.deleted_at IS NULL`,
+	SearchTypeProduct: `SELECT 'product' AS type, products.id, products.title, products.slug, products.image,
+		ts_rank(products.search_vector, q.query) AS rank,
+		ts_headline('desa_search', regexp_replace(products.content, '<[^>]*>', ' ', 'g'), q.query, @headline) AS snippet,
+		products.updated_at
+		FROM products, q WHERE products.search_vector @@ q.query AND products.deleted_at IS NULL`,
+	SearchTypePhoto: `SELECT 'photo' AS type, photos.id, photos.caption AS title, '' AS slug, photos.image,
+		ts_rank(photos.search_vector, q.query) AS rank,
+		ts_headline('desa_search', coalesce(photos.description, ''), q.query, @headline) AS snippet,
+		photos.updated_at
+		FROM photos, q WHERE photos.search_vector @@ q.query AND photos.deleted_at IS NULL`,
+	SearchTypeAparatur: `SELECT 'aparatur' AS type, aparaturs.id, aparaturs.name AS title, '' AS slug, aparaturs.image,
+		ts_rank(aparaturs.search_vector, q.query) AS rank,
+		ts_headline('desa_search', coalesce(aparaturs.position, '') || ' ' || coalesce(aparaturs.description, ''), q.query, @headline) AS snippet,
+		aparaturs.updated_at
+		FROM aparaturs, q WHERE aparaturs.search_vector @@ q.query AND aparaturs.deleted_at IS NULL`,
+}
+
+var searchTypeOrder = []string{SearchTypePost, SearchTypePage, SearchTypeProduct, SearchTypePhoto, SearchTypeAparatur}
+
+type searchRow struct {
+	Type      string
+	ID        uint
+	Title     string
+	Slug      string
+	Image     string
+	Rank      float64
+	Snippet   string
+	UpdatedAt time.Time
+}
+
+// SearchContent runs a ranked full-text search over the public content.
+// An empty types list searches every type; unknown types are ignored.
+func SearchContent(query string, types []string, limit, offset int) ([]structs.SearchResultResponse, int64, error) {
+	wanted := map[string]bool{}
+	for _, t := range types {
+		wanted[strings.TrimSpace(t)] = true
+	}
+
+	selects := []string{}
+	for _, t := range searchTypeOrder {
+		if len(types) == 0 || wanted[t] {
+			selects = append(selects, searchSources[t])
+		}
+	}
+
+	results := []structs.SearchResultResponse{}
+	if len(selects) == 0 {
+		return results, 0, nil
+	}
+
+	union := "WITH q AS (SELECT websearch_to_tsquery('desa_search', @q) AS query) " +
+		"SELECT * FROM (" + strings.Join(selects, " UNION ALL ") + ") AS results"
+	args := map[string]any{
+		"q":        query,
+		"status":   models.PostStatusPublished,
+		"now":      time.Now(),
+		"headline": "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10",
+	}
+
+	var total int64
+	if err := database.DB.Raw("SELECT COUNT(*) FROM ("+union+") AS counted", args).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	args["limit"] = limit
+	args["offset"] = offset
+
+	var rows []searchRow
+	err := database.DB.Raw(union+" ORDER BY rank DESC, updated_at DESC LIMIT @limit OFFSET @offset", args).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, row := range rows {
+		results = append(results, structs.SearchResultResponse{
+			Type:      row.Type,
+			ID:        row.ID,
+			Title:     row.Title,
+			Slug:      row.Slug,
+			Image:     row.Image,
+			Snippet:   row.Snippet,
+			Rank:      row.Rank,
+			UpdatedAt: row.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return results, total, nil
+}
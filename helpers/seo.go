@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+)
+
+const metaDescriptionLength = 160
+
+func SEOFromRequest(req structs.SEORequest) models.SEO {
+	return models.SEO{
+		MetaTitle:       req.MetaTitle,
+		MetaDescription: req.MetaDescription,
+		CanonicalURL:    req.CanonicalURL,
+		OGImage:         req.OGImage,
+	}
+}
+
+// SEOResponse returns the stored values as they are, for the admin forms.
+func SEOResponse(seo models.SEO) *structs.SEOResponse {
+	return &structs.SEOResponse{
+		MetaTitle:       seo.MetaTitle,
+		MetaDescription: seo.MetaDescription,
+		CanonicalURL:    seo.CanonicalURL,
+		OGImage:         seo.OGImage,
+	}
+}
+
+// ResolveSEO fills the empty fields with values derived from the content,
+// so link previews always have a title, description and URL.
+func ResolveSEO(seo models.SEO, title, content, canonicalURL, image, ogType string) *structs.SEOResponse {
+	response := SEOResponse(seo)
+	response.OGType = ogType
+
+	if response.MetaTitle == "" {
+		response.MetaTitle = title
+	}
+	if response.MetaDescription == "" {
+		response.MetaDescription = Excerpt(content, metaDescriptionLength)
+	}
+	if response.CanonicalURL == "" {
+		response.CanonicalURL = canonicalURL
+	}
+	if response.OGImage == "" {
+		response.OGImage = image
+	}
+
+	return response
+}
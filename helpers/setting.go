@@ -0,0 +1,24 @@
+package helpers
+
+import (
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+)
+
+const SettingTwoFactorRequired = "two_factor_required"
+
+func GetSetting(key, defaultValue string) string {
+	var setting models.Setting
+	if err := database.DB.Where("key = ?", key).First(&setting).Error; err != nil {
+		return defaultValue
+	}
+	return setting.Value
+}
+
+func SetSetting(key, value string) error {
+	var setting models.Setting
+	return database.DB.
+		Where(models.Setting{Key: key}).
+		Assign(models.Setting{Value: value}).
+		FirstOrCreate(&setting).Error
+}
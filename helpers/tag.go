@@ -0,0 +1,45 @@
+package helpers
+
+import (
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"gorm.io/gorm"
+)
+
+// ResolveTags maps tag names to tag rows, creating the ones that don't
+// exist yet. Names that slugify to the same value are treated as one tag.
+func ResolveTags(db *gorm.DB, names []string) ([]models.Tag, error) {
+	tags := []models.Tag{}
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		slug := Slugify(name)
+		if slug == "" || seen[slug] {
+			continue
+		}
+		seen[slug] = true
+
+		var tag models.Tag
+		if err := db.Where(models.Tag{Slug: slug}).Attrs(models.Tag{Name: name}).FirstOrCreate(&tag).Error; err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func TagSimpleResponses(tags []models.Tag) []structs.TagSimpleResponse {
+	responses := []structs.TagSimpleResponse{}
+	for _, tag := range tags {
+		responses = append(responses, structs.TagSimpleResponse{
+			ID:   tag.ID,
+			Name: tag.Name,
+			Slug: tag.Slug,
+		})
+	}
+	return responses
+}
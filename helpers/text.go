@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	tagPattern        = regexp.MustCompile(`<[^>]*>`)
+	whitespacePattern = regexp.MustCompile(`\s+`)
+)
+
+// StripTags turns HTML into plain text with collapsed whitespace.
+func StripTags(content string) string {
+	text := tagPattern.ReplaceAllString(content, " ")
+	text = html.UnescapeString(text)
+	return strings.TrimSpace(whitespacePattern.ReplaceAllString(text, " "))
+}
+
+// Excerpt returns the plain text of content cut at a word boundary so it
+// fits in max characters, with an ellipsis when something was cut.
+func Excerpt(content string, max int) string {
+	text := StripTags(content)
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	runes := []rune(text)[:max]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/models"
+)
+
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+func FormatNullableTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(DateTimeLayout)
+}
+
+// ResolvePublishedAt decides the publish time for a post. An explicit value
+// wins (a future one schedules the post), otherwise a post being published
+// for the first time goes live now and everything else keeps what it had.
+func ResolvePublishedAt(status, publishedAt string, current *time.Time) (*time.Time, error) {
+	if publishedAt != "" {
+		t, err := time.ParseInLocation(DateTimeLayout, publishedAt, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+
+	if status == models.PostStatusPublished && current == nil {
+		now := time.Now()
+		return &now, nil
+	}
+
+	return current, nil
+}
+
+const DateLayout = "2006-01-02"
+
+func FormatNullableDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(DateLayout)
+}
+
+// ParseNullableDate turns an optional "2006-01-02" value into a date, an
+// empty string clears it.
+func ParseNullableDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.ParseInLocation(DateLayout, value, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
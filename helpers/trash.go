@@ -0,0 +1,168 @@
+package helpers
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"gorm.io/gorm"
+)
+
+// TrashEntity describes content that is soft deleted into the trash before
+// it is purged for good.
+type TrashEntity struct {
+	// Type is the entity name used by audit logs, revisions and media usage.
+	Type string
+	// Directory holds the uploaded image, empty when there is none.
+	Directory   string
+	TitleColumn string
+	Model       func() any
+}
+
+// TrashEntities are keyed by their route segment, bulk actions use them as
+// well.
+var TrashEntities = map[string]TrashEntity{
+	"posts":     {Type: "post", Directory: "posts", TitleColumn: "title", Model: func() any { return &models.Post{} }},
+	"pages":     {Type: "page", TitleColumn: "title", Model: func() any { return &models.Page{} }},
+	"products":  {Type: "product", Directory: "products", TitleColumn: "title", Model: func() any { return &models.Product{} }},
+	"photos":    {Type: "photo", Directory: "photos", TitleColumn: "caption", Model: func() any { return &models.Photo{} }},
+	"sliders":   {Type: "slider", Directory: "sliders", TitleColumn: "description", Model: func() any { return &models.Slider{} }},
+	"aparaturs": {Type: "aparatur", Directory: "aparaturs", TitleColumn: "name", Model: func() any { return &models.Aparatur{} }},
+	"albums":    {Type: "album", Directory: "albums", TitleColumn: "title", Model: func() any { return &models.Album{} }},
+}
+
+type TrashedItem struct {
+	ID        uint
+	Title     string
+	Image     string
+	DeletedAt time.Time
+}
+
+func (entity TrashEntity) Trashed() *gorm.DB {
+	return database.DB.Unscoped().Model(entity.Model()).Where("deleted_at IS NOT NULL")
+}
+
+// ItemColumns selects a trashed row as TrashedItem.
+func (entity TrashEntity) ItemColumns() string {
+	image := "'' AS image"
+	if entity.Directory != "" {
+		image = "image"
+	}
+	return "id, " + entity.TitleColumn + " AS title, " + image + ", deleted_at"
+}
+
+// Restore takes a trashed row out of the trash and returns it.
+func (entity TrashEntity) Restore(id uint) (any, error) {
+	result := database.DB.Unscoped().Model(entity.Model()).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	model := entity.Model()
+	if err := database.DB.First(model, id).Error; err != nil {
+		return nil, err
+	}
+	return model, nil
+}
+
+// Purge deletes a trashed row for good along with its revisions, media
+// usage and uploaded images. Media usage is kept while a row sits in the
+// trash so its media can't be deleted before a restore, it is only released
+// here. Files that can't be removed are only logged, a reconcile run picks
+// them up later.
+func (entity TrashEntity) Purge(id uint) (any, error) {
+	model := entity.Model()
+	if err := database.DB.Unscoped().Where("deleted_at IS NOT NULL").First(model, id).Error; err != nil {
+		return nil, err
+	}
+
+	var item TrashedItem
+	if err := entity.Trashed().Select(entity.ItemColumns()).Where("id = ?", id).Scan(&item).Error; err != nil {
+		return nil, err
+	}
+
+	images := []string{}
+	if item.Image != "" {
+		images = append(images, item.Image)
+	}
+
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var revisionImages []string
+		tx.Model(&models.Revision{}).
+			Where("entity_type = ? AND entity_id = ? AND image <> '' AND image <> ?", entity.Type, id, item.Image).
+			Distinct().Pluck("image", &revisionImages)
+		images = append(images, revisionImages...)
+
+		if err := tx.Where("entity_type = ? AND entity_id = ?", entity.Type, id).Delete(&models.Revision{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("entity_type = ? AND entity_id = ?", entity.Type, id).Delete(&models.MediaUsage{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(model).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if entity.Directory != "" {
+		for _, image := range images {
+			if err := RemoveUpload(entity.Directory, image); err != nil {
+				log.Printf("failed to remove %s/%s: %v", entity.Directory, image, err)
+			}
+		}
+	}
+
+	return model, nil
+}
+
+// TrashRetention is how long trashed content is kept, from
+// TRASH_RETENTION_DAYS. Zero keeps it until it is purged by hand.
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(config.GetEnv("TRASH_RETENTION_DAYS", "30"))
+	if err != nil || days < 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// PurgeExpiredTrash purges everything that has been in the trash longer
+// than TrashRetention.
+func PurgeExpiredTrash() error {
+	retention := TrashRetention()
+	if retention == 0 {
+		return nil
+	}
+
+	purged := 0
+	for _, entity := range TrashEntities {
+		var ids []uint
+		err := entity.Trashed().Where("deleted_at < ?", time.Now().Add(-retention)).Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			model, err := entity.Purge(id)
+			if err != nil {
+				log.Printf("failed to purge %s %d: %v", entity.Type, id, err)
+				continue
+			}
+			RecordSystemAudit(AuditActionPurge, entity.Type, id, model, nil)
+			purged++
+		}
+	}
+
+	if purged > 0 {
+		log.Printf("purged %d trashed items", purged)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 8
+
+// RequiresTwoFactor reports whether the permission set is sensitive enough
+// to fall under the two-factor policy (anyone who can manage users or roles).
+func RequiresTwoFactor(permissions map[string]bool) bool {
+	for name, granted := range permissions {
+		if granted && (strings.HasPrefix(name, "users-") || strings.HasPrefix(name, "roles-")) {
+			return true
+		}
+	}
+	return false
+}
+
+func TwoFactorPolicyEnabled() bool {
+	return GetSetting(SettingTwoFactorRequired, "false") == "true"
+}
+
+// VerifyTwoFactorCode validates a TOTP code and remembers the used time step
+// so the same code can't be replayed within its validity window.
+func VerifyTwoFactorCode(user *models.User, code string) bool {
+	if user.TwoFactorSecret == "" {
+		return false
+	}
+
+	step, ok := ValidateTOTP(user.TwoFactorSecret, code, time.Now())
+	if !ok || step <= user.TwoFactorLastStep {
+		return false
+	}
+
+	result := database.DB.Model(&models.User{}).
+		Where("id = ? AND two_factor_last_step < ?", user.ID, step).
+		Update("two_factor_last_step", step)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return false
+	}
+
+	user.TwoFactorLastStep = step
+	return true
+}
+
+func UseRecoveryCode(userID uint, code string) bool {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if code == "" {
+		return false
+	}
+
+	result := database.DB.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, HashToken(code)).
+		Update("used_at", time.Now())
+
+	return result.Error == nil && result.RowsAffected == 1
+}
+
+func ReplaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	codes, err := GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]models.RecoveryCode, 0, len(codes))
+	for _, code := range codes {
+		recoveryCodes = append(recoveryCodes, models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: HashToken(code),
+		})
+	}
+
+	if err := tx.Create(&recoveryCodes).Error; err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
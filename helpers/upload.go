@@ -1,32 +1,60 @@
 package helpers
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"image"
+	"io"
+	"log"
 	"mime/multipart"
-	"os"
+	"net/http"
 	"path/filepath"
 	"slices"
 	"strings"
 
+	"github.com/ahmadalaik/desa-digital/storage"
 	"github.com/ahmadalaik/desa-digital/structs"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type UploadConfig struct {
-	File           *multipart.FileHeader
-	AllowedTypes   []string
-	MaxSize        int64
-	DestinationDir string
+	File         *multipart.FileHeader
+	AllowedTypes []string
+	MaxSize      int64
+	// Directory is the first segment of the storage key, e.g. "posts".
+	Directory string
+	// UploadID takes a finished resumable upload instead of File.
+	UploadID string
 }
 
 type UploadResult struct {
-	FileName string
-	FilePath string
+	FileName     string
+	OriginalName string
+	// FilePath is the storage key of the saved file.
+	FilePath    string
+	ContentType string
+	Size        int64
+	// Width and Height are only set for images.
+	Width    int
+	Height   int
 	Error    error
 	Response *structs.ErrorResponse
 }
 
+// uploadContentTypes maps an extension to the content types its bytes may
+// sniff as, extensions missing here can't be verified and are refused.
+var uploadContentTypes = map[string][]string{
+	".jpg":  {"image/jpeg"},
+	".jpeg": {"image/jpeg"},
+	".png":  {"image/png"},
+	".gif":  {"image/gif"},
+	".pdf":  {"application/pdf"},
+	".mp4":  {"video/mp4"},
+	".webm": {"video/webm"},
+}
+
 func SlugifyFileName(filename string) string {
 	ext := filepath.Ext(filename)
 	base := strings.TrimSuffix(filename, ext)
@@ -37,6 +65,10 @@ func SlugifyFileName(filename string) string {
 }
 
 func UploadFile(c *gin.Context, config UploadConfig) UploadResult {
+	if config.File == nil && config.UploadID != "" {
+		return uploadFromSession(c, config)
+	}
+
 	if config.File == nil {
 		return UploadResult{
 			Response: &structs.ErrorResponse{
@@ -47,7 +79,30 @@ func UploadFile(c *gin.Context, config UploadConfig) UploadResult {
 		}
 	}
 
-	if config.File.Size > config.MaxSize {
+	return saveUpload(config, config.File.Filename, config.File.Size, func() (io.ReadCloser, error) {
+		return config.File.Open()
+	})
+}
+
+// RequestedUpload finds the file sent for field: either the multipart file
+// itself or the ID of a finished resumable upload in "<field>_upload_id",
+// taken from the form or the query string.
+func RequestedUpload(c *gin.Context, field string) (*multipart.FileHeader, string, bool) {
+	if file, err := c.FormFile(field); err == nil {
+		return file, "", true
+	}
+
+	uploadID := c.PostForm(field + "_upload_id")
+	if uploadID == "" {
+		uploadID = c.Query(field + "_upload_id")
+	}
+	return nil, uploadID, uploadID != ""
+}
+
+// saveUpload validates a file and puts it into storage. Images are decoded
+// and encoded again in memory, everything else is streamed.
+func saveUpload(config UploadConfig, originalName string, size int64, open func() (io.ReadCloser, error)) UploadResult {
+	if size > config.MaxSize {
 		return UploadResult{
 			Response: &structs.ErrorResponse{
 				Success: false,
@@ -57,17 +112,8 @@ func UploadFile(c *gin.Context, config UploadConfig) UploadResult {
 		}
 	}
 
-	ext := strings.ToLower(filepath.Ext(config.File.Filename))
-	allowed := false
-
-	// for _, t := range config.AllowedTypes {
-	// 	if ext == t {
-	// 		allowed = true
-	// 		break
-	// 	}
-	// }
-
-	allowed = slices.Contains(config.AllowedTypes, ext)
+	ext := strings.ToLower(filepath.Ext(originalName))
+	allowed := slices.Contains(config.AllowedTypes, ext)
 
 	if !allowed {
 		return UploadResult{
@@ -79,22 +125,69 @@ func UploadFile(c *gin.Context, config UploadConfig) UploadResult {
 		}
 	}
 
-	uuidName := uuid.New().String()
+	src, err := open()
+	if err != nil {
+		return UploadResult{
+			Response: &structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to read file",
+				Errors:  map[string]string{"file": err.Error()},
+			},
+		}
+	}
+	defer src.Close()
 
-	fileName := uuidName + ext
-	filePath := filepath.Join(config.DestinationDir, fileName)
+	header := make([]byte, 512)
+	n, err := io.ReadFull(src, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return UploadResult{
+			Response: &structs.ErrorResponse{
+				Success: false,
+				Message: "Failed to read file",
+				Errors:  map[string]string{"file": err.Error()},
+			},
+		}
+	}
+	header = header[:n]
 
-	if err := os.MkdirAll(config.DestinationDir, 0755); err != nil {
+	contentType := http.DetectContentType(header)
+	if !slices.Contains(uploadContentTypes[ext], strings.TrimSpace(strings.Split(contentType, ";")[0])) {
 		return UploadResult{
 			Response: &structs.ErrorResponse{
 				Success: false,
-				Message: "Failed to create upload directory",
-				Errors:  map[string]string{"system": err.Error()},
+				Message: "Invalid file type",
+				Errors:  map[string]string{"file": fmt.Sprintf("File content (%s) does not match the %s extension", contentType, ext)},
 			},
 		}
 	}
 
-	if err := c.SaveUploadedFile(config.File, filePath); err != nil {
+	fileName := uuid.New().String() + ext
+	filePath := storage.Key(config.Directory, fileName)
+	body := io.MultiReader(bytes.NewReader(header), src)
+
+	var data []byte
+	if IsImageFile(ext) {
+		data, err = io.ReadAll(io.LimitReader(body, config.MaxSize))
+		if err == nil {
+			data, err = ReencodeImage(data, MaxImagePixels())
+		}
+		if err != nil {
+			message := "Invalid image file"
+			if errors.Is(err, ErrImageTooLarge) {
+				message = "Image dimensions too large"
+			}
+			return UploadResult{
+				Response: &structs.ErrorResponse{
+					Success: false,
+					Message: message,
+					Errors:  map[string]string{"file": err.Error()},
+				},
+			}
+		}
+		body, size = bytes.NewReader(data), int64(len(data))
+	}
+
+	if err := storage.Default.Put(filePath, body, size, contentType); err != nil {
 		return UploadResult{
 			Response: &structs.ErrorResponse{
 				Success: false,
@@ -104,8 +197,24 @@ func UploadFile(c *gin.Context, config UploadConfig) UploadResult {
 		}
 	}
 
-	return UploadResult{
-		FileName: fileName,
-		FilePath: filePath,
+	result := UploadResult{
+		FileName:     fileName,
+		OriginalName: originalName,
+		FilePath:     filePath,
+		ContentType:  contentType,
+		Size:         size,
 	}
+
+	// the original is still usable when variants can't be made, so this
+	// only gets logged
+	if data != nil {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(data)); err == nil {
+			result.Width, result.Height = cfg.Width, cfg.Height
+		}
+		if err := ProcessImage(config.Directory, fileName, data); err != nil {
+			log.Printf("failed to process image %s: %v", filePath, err)
+		}
+	}
+
+	return result
 }
@@ -0,0 +1,235 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/structs"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrUploadChecksumMismatch = errors.New("checksum does not match the uploaded data")
+
+// UploadSessionDir is where resumable uploads are assembled. Every app
+// instance must see the same directory, the temporary directory default
+// only suits a single instance.
+func UploadSessionDir() string {
+	if dir := config.GetEnv("UPLOAD_SESSION_DIR", ""); dir != "" {
+		return dir
+	}
+	return filepath.Join(os.TempDir(), "desa-digital-uploads")
+}
+
+// CheckUploadSessionDir insists on an explicit UPLOAD_SESSION_DIR when
+// files are kept outside the local disk, as they are once several
+// instances run side by side.
+func CheckUploadSessionDir() error {
+	if config.GetEnv("UPLOAD_SESSION_DIR", "") == "" && config.GetEnv("STORAGE_DRIVER", "local") != "local" {
+		return errors.New("UPLOAD_SESSION_DIR must be set to a directory shared by every app instance when STORAGE_DRIVER is not local")
+	}
+	return nil
+}
+
+func UploadSessionPath(id string) string {
+	return filepath.Join(UploadSessionDir(), id+".part")
+}
+
+// UploadSessionTTL is how long an upload may sit idle before it expires.
+func UploadSessionTTL() time.Duration {
+	hours, err := strconv.Atoi(config.GetEnv("UPLOAD_SESSION_TTL_HOURS", "24"))
+	if err != nil || hours <= 0 {
+		hours = 24
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+func UploadSessionMaxSize() int64 {
+	size, err := strconv.ParseInt(config.GetEnv("UPLOAD_SESSION_MAX_SIZE", strconv.Itoa(512<<20)), 10, 64)
+	if err != nil || size <= 0 {
+		return 512 << 20
+	}
+	return size
+}
+
+// UploadSessionUserLimits are how many unexpired uploads a user may have
+// open at once and how many bytes they may add up to, from
+// UPLOAD_SESSIONS_PER_USER and UPLOAD_SESSION_USER_QUOTA.
+func UploadSessionUserLimits() (int64, int64) {
+	sessions, err := strconv.ParseInt(config.GetEnv("UPLOAD_SESSIONS_PER_USER", "5"), 10, 64)
+	if err != nil || sessions <= 0 {
+		sessions = 5
+	}
+	quota, err := strconv.ParseInt(config.GetEnv("UPLOAD_SESSION_USER_QUOTA", strconv.Itoa(1<<30)), 10, 64)
+	if err != nil || quota <= 0 {
+		quota = 1 << 30
+	}
+	return sessions, quota
+}
+
+// UploadSessionUsage returns the number of unexpired uploads of a user and
+// the bytes they reserve.
+func UploadSessionUsage(userID uint) (int64, int64, error) {
+	var usage struct {
+		Sessions int64
+		Bytes    int64
+	}
+	err := database.DB.Model(&models.UploadSession{}).
+		Select("COUNT(*) AS sessions, COALESCE(SUM(size), 0) AS bytes").
+		Where("user_id = ? AND expires_at > ?", userID, time.Now()).
+		Scan(&usage).Error
+	return usage.Sessions, usage.Bytes, err
+}
+
+// UploadChunkMaxSize caps the body of a single PATCH request.
+func UploadChunkMaxSize() int64 {
+	size, err := strconv.ParseInt(config.GetEnv("UPLOAD_CHUNK_MAX_SIZE", strconv.Itoa(16<<20)), 10, 64)
+	if err != nil || size <= 0 {
+		return 16 << 20
+	}
+	return size
+}
+
+func UploadContentType(fileName string) string {
+	types := uploadContentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if len(types) == 0 {
+		return "application/octet-stream"
+	}
+	return types[0]
+}
+
+func IsUploadExtensionAllowed(fileName string) bool {
+	_, ok := uploadContentTypes[strings.ToLower(filepath.Ext(fileName))]
+	return ok
+}
+
+// WriteUploadChunk writes data at offset, dropping anything a broken
+// earlier request may have left past it.
+func WriteUploadChunk(session models.UploadSession, offset int64, data []byte) error {
+	if err := os.MkdirAll(UploadSessionDir(), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(UploadSessionPath(session.ID), os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := file.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := file.WriteAt(data, offset); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func VerifyUploadChecksum(session models.UploadSession) error {
+	if session.Checksum == "" {
+		return nil
+	}
+
+	file, err := os.Open(UploadSessionPath(session.ID))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return err
+	}
+	if hex.EncodeToString(hash.Sum(nil)) != session.Checksum {
+		return ErrUploadChecksumMismatch
+	}
+	return nil
+}
+
+func RemoveUploadSession(session models.UploadSession) {
+	if err := database.DB.Delete(&session).Error; err != nil {
+		log.Println("failed to delete upload session:", err)
+	}
+	if err := os.Remove(UploadSessionPath(session.ID)); err != nil && !os.IsNotExist(err) {
+		log.Println("failed to remove upload session data:", err)
+	}
+}
+
+// PurgeExpiredUploadSessions drops uploads that were abandoned or finished
+// but never used.
+func PurgeExpiredUploadSessions() error {
+	var sessions []models.UploadSession
+	if err := database.DB.Where("expires_at < ?", time.Now()).Find(&sessions).Error; err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		RemoveUploadSession(session)
+	}
+	if len(sessions) > 0 {
+		log.Printf("purged %d expired upload sessions", len(sessions))
+	}
+	return nil
+}
+
+func UploadSessionResponse(session models.UploadSession) structs.UploadSessionResponse {
+	return structs.UploadSessionResponse{
+		ID:          session.ID,
+		FileName:    session.FileName,
+		Size:        session.Size,
+		Offset:      session.Offset,
+		Checksum:    session.Checksum,
+		Status:      session.Status,
+		ExpiresAt:   session.ExpiresAt.Format("2006-01-02 15:04:05"),
+		CompletedAt: FormatNullableTime(session.CompletedAt),
+		CreatedAt:   session.CreatedAt.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// uploadFromSession stores a finished resumable upload of the current user
+// like a regular file upload and discards the session. The session is
+// claimed first so two requests can't both use the same upload, it is
+// handed back when storing fails and the client may try again.
+func uploadFromSession(c *gin.Context, config UploadConfig) UploadResult {
+	userID, _ := AuthUserID(c)
+	notFound := UploadResult{
+		Response: &structs.ErrorResponse{
+			Success: false,
+			Message: "Upload not found",
+			Errors:  map[string]string{"upload_id": "No finished upload with this ID"},
+		},
+	}
+
+	claim := database.DB.Model(&models.UploadSession{}).
+		Where("id = ? AND user_id = ? AND status = ?", config.UploadID, userID, models.UploadSessionStatusCompleted).
+		Update("status", models.UploadSessionStatusClaimed)
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return notFound
+	}
+
+	var session models.UploadSession
+	if err := database.DB.First(&session, "id = ?", config.UploadID).Error; err != nil {
+		return notFound
+	}
+
+	result := saveUpload(config, session.FileName, session.Size, func() (io.ReadCloser, error) {
+		return os.Open(UploadSessionPath(session.ID))
+	})
+	if result.Response != nil {
+		database.DB.Model(&session).Update("status", models.UploadSessionStatusCompleted)
+		return result
+	}
+
+	RemoveUploadSession(session)
+	return result
+}
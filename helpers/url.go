@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/storage"
+)
+
+// SiteURL is the public base URL used for absolute links, from APP_URL.
+// It is never taken from the request, whose Host and X-Forwarded-Proto
+// headers are up to the client.
+func SiteURL() string {
+	return strings.TrimRight(config.GetEnv("APP_URL", ""), "/")
+}
+
+// CheckSiteURL reports an APP_URL that is missing or not an absolute
+// http(s) URL, the app refuses to start without one.
+func CheckSiteURL() error {
+	u, err := url.Parse(SiteURL())
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("APP_URL must be the absolute public URL of the site, e.g. https://desa.example.id")
+	}
+	return nil
+}
+
+// TrustedProxies lists the proxies, as IPs or CIDRs, whose X-Forwarded-For
+// header is believed, from TRUSTED_PROXIES. Empty trusts none so ClientIP is
+// always the connecting address and can't be picked by the client.
+func TrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(config.GetEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+func UploadURL(siteURL, dir, fileName string) string {
+	if fileName == "" {
+		return ""
+	}
+	fileURL := storage.Default.URL(storage.Key(dir, fileName))
+	// local files are served by the app itself under a relative path
+	if strings.HasPrefix(fileURL, "/") {
+		return siteURL + fileURL
+	}
+	return fileURL
+}
+
+func urlHost(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Hostname(), nil
+}
@@ -1,10 +1,12 @@
 package helpers
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
+	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/go-playground/validator/v10"
 	"gorm.io/gorm"
 )
@@ -28,6 +30,18 @@ func TranslateErrorMessage(err error) map[string]string {
 				errorsMap[field] = fmt.Sprintf("%s must be at least %s characters", field, fieldError.Param())
 			case "numeric":
 				errorsMap[field] = fmt.Sprintf("%s must be a number", field)
+			case "oneof":
+				errorsMap[field] = fmt.Sprintf("%s must be one of: %s", field, fieldError.Param())
+			case "datetime":
+				errorsMap[field] = fmt.Sprintf("%s must use the format %s", field, fieldError.Param())
+			case "url":
+				errorsMap[field] = fmt.Sprintf("%s must be a valid URL", field)
+			case "len":
+				errorsMap[field] = fmt.Sprintf("%s must be exactly %s characters", field, fieldError.Param())
+			case "hexadecimal":
+				errorsMap[field] = fmt.Sprintf("%s must be hexadecimal", field)
+			case "eqfield":
+				errorsMap[field] = fmt.Sprintf("%s must match %s", field, fieldError.Param())
 			default:
 				errorsMap[field] = "invalid value"
 			}
@@ -65,3 +79,12 @@ func extractDuplicateField(errMsg string) string {
 	}
 	return ""
 }
+
+// IsDuplicateKey reports whether err is a unique constraint violation of
+// the database driver.
+func IsDuplicateKey(err error) bool {
+	if translator, ok := database.DB.Dialector.(gorm.ErrorTranslator); ok {
+		err = translator.Translate(err)
+	}
+	return errors.Is(err, gorm.ErrDuplicatedKey)
+}
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
 	"github.com/ahmadalaik/desa-digital/config"
 	"github.com/ahmadalaik/desa-digital/database"
 	"github.com/ahmadalaik/desa-digital/database/seeders"
+	"github.com/ahmadalaik/desa-digital/helpers"
+	"github.com/ahmadalaik/desa-digital/maintenance"
 	"github.com/ahmadalaik/desa-digital/routes"
+	"github.com/ahmadalaik/desa-digital/storage"
 )
 
 func main() {
@@ -12,9 +20,87 @@ func main() {
 
 	database.InitDB()
 
+	storage.Init()
+
+	// go run . reconcile [-mode dry-run|delete|quarantine]
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		reconcile(os.Args[2:])
+		return
+	}
+
+	// go run . variants [-force]
+	if len(os.Args) > 1 && os.Args[1] == "variants" {
+		variants(os.Args[2:])
+		return
+	}
+
+	if err := helpers.CheckSiteURL(); err != nil {
+		log.Fatal(err)
+	}
+	if err := helpers.CheckUploadSessionDir(); err != nil {
+		log.Fatal(err)
+	}
+
+	// looks cwebp up now so a missing encoder is logged at startup
+	helpers.WebPEnabled()
+
 	seeders.Seed()
 
+	maintenance.Start()
+
 	r := routes.SetupRouter()
 
 	r.Run(":" + config.GetEnv("APP_PORT", "8080"))
 }
+
+func reconcile(args []string) {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	mode := flags.String("mode", helpers.ReconcileModeDryRun, "dry-run, delete or quarantine")
+	flags.Parse(args)
+
+	switch *mode {
+	case helpers.ReconcileModeDryRun, helpers.ReconcileModeDelete, helpers.ReconcileModeQuarantine:
+	default:
+		log.Fatalf("unknown mode %q", *mode)
+	}
+
+	report, err := helpers.ReconcileUploads(*mode)
+	if err != nil {
+		log.Fatal("Failed to reconcile uploads: ", err)
+	}
+	if *mode != helpers.ReconcileModeDryRun {
+		helpers.RecordSystemAudit(helpers.AuditActionReconcile, "storage", 0, nil, report)
+	}
+
+	for _, orphan := range report.Orphans {
+		if orphan.Error != "" {
+			fmt.Printf("orphan  %s (%d bytes) failed: %s\n", orphan.Key, orphan.Size, orphan.Error)
+			continue
+		}
+		fmt.Printf("orphan  %s (%d bytes) %s\n", orphan.Key, orphan.Size, orphan.Action)
+	}
+	for _, missing := range report.Missing {
+		fmt.Printf("missing %s (%s %d)\n", missing.Key, missing.EntityType, missing.EntityID)
+	}
+	fmt.Printf("%s: scanned %d files, %d orphans (%d bytes), %d missing\n",
+		report.Mode, report.Scanned, len(report.Orphans), report.OrphanBytes, len(report.Missing))
+}
+
+func variants(args []string) {
+	flags := flag.NewFlagSet("variants", flag.ExitOnError)
+	force := flags.Bool("force", false, "process every image again, not only those missing a variant")
+	flags.Parse(args)
+
+	report, err := helpers.BackfillImageVariants(*force)
+	if err != nil {
+		log.Fatal("Failed to backfill image variants: ", err)
+	}
+
+	for _, key := range report.Processed {
+		fmt.Printf("processed %s\n", key)
+	}
+	for key, message := range report.Failed {
+		fmt.Printf("failed    %s: %s\n", key, message)
+	}
+	fmt.Printf("scanned %d images, %d processed, %d failed\n", report.Scanned, len(report.Processed), len(report.Failed))
+}
@@ -0,0 +1,46 @@
+package maintenance
+
+import (
+	"log"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/helpers"
+)
+
+// tasks run one after another on every tick, a failing task is logged and
+// tried again on the next one.
+var tasks = []struct {
+	name string
+	run  func() error
+}{
+	{"purge expired upload sessions", helpers.PurgeExpiredUploadSessions},
+	{"purge expired trash", helpers.PurgeExpiredTrash},
+}
+
+// Start runs the maintenance tasks in the background every
+// MAINTENANCE_INTERVAL (a Go duration, 1h by default).
+func Start() {
+	interval, err := time.ParseDuration(config.GetEnv("MAINTENANCE_INTERVAL", "1h"))
+	if err != nil || interval <= 0 {
+		interval = time.Hour
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			run()
+			<-ticker.C
+		}
+	}()
+}
+
+func run() {
+	for _, task := range tasks {
+		if err := task.run(); err != nil {
+			log.Printf("maintenance: %s failed: %v", task.name, err)
+		}
+	}
+}
@@ -3,8 +3,11 @@ package middlewares
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/ahmadalaik/desa-digital/config"
+	"github.com/ahmadalaik/desa-digital/database"
+	"github.com/ahmadalaik/desa-digital/models"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 )
@@ -30,7 +33,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		token, err := jwt.ParseWithClaims(tokenStr, claims, func(t *jwt.Token) (any, error) {
 			return jwtKey, nil
 		})
-		if err != nil || !token.Valid {
+		if err != nil || !token.Valid || claims.ID == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "Unauthenticated",
 			})
@@ -38,7 +41,20 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		var session models.Session
+		err = database.DB.
+			Where("uuid = ? AND revoked_at IS NULL AND expires_at > ?", claims.ID, time.Now()).
+			First(&session).Error
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Session has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		c.Set("username", claims.Subject)
+		c.Set("session_id", session.ID)
 		c.Next()
 	}
 }
@@ -3,8 +3,7 @@ package middlewares
 import (
 	"net/http"
 
-	"github.com/ahmadalaik/desa-digital/database"
-	"github.com/ahmadalaik/desa-digital/models"
+	"github.com/ahmadalaik/desa-digital/helpers"
 	"github.com/gin-gonic/gin"
 )
 
@@ -17,24 +16,24 @@ func Permission(permissionName string) gin.HandlerFunc {
 			return
 		}
 
-		var user models.User
-		err := database.DB.
-			Preload("Roles.Permissions").
-			Where("username = ?", username).
-			First(&user).Error
+		resolved, err := helpers.ResolvePermissions(username.(string))
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
 			c.Abort()
 			return
 		}
 
-		for _, role := range user.Roles {
-			for _, perm := range role.Permissions {
-				if perm.Name == permissionName {
-					c.Next()
-					return
-				}
-			}
+		// users who can manage accounts must enrol in 2FA before doing anything
+		// else once the policy is switched on
+		if resolved.TwoFactorPending {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication must be enabled for this account"})
+			c.Abort()
+			return
+		}
+
+		if resolved.Permissions[permissionName] {
+			c.Next()
+			return
 		}
 
 		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden - permission denied"})
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type rateLimitWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+// RateLimit allows at most limit requests per client IP within window for
+// the route it is attached to. The IP only comes from X-Forwarded-For when
+// the request passed a proxy listed in TRUSTED_PROXIES. Counters live in
+// memory, so every instance keeps its own.
+func RateLimit(limit int, window time.Duration) gin.HandlerFunc {
+	var mu sync.Mutex
+	windows := map[string]*rateLimitWindow{}
+	lastSweep := time.Now()
+
+	return func(c *gin.Context) {
+		now := time.Now()
+		key := c.ClientIP()
+
+		mu.Lock()
+		if now.Sub(lastSweep) > window {
+			for k, w := range windows {
+				if now.After(w.resetAt) {
+					delete(windows, k)
+				}
+			}
+			lastSweep = now
+		}
+
+		w, ok := windows[key]
+		if !ok || now.After(w.resetAt) {
+			w = &rateLimitWindow{resetAt: now.Add(window)}
+			windows[key] = w
+		}
+		w.count++
+		count, resetAt := w.count, w.resetAt
+		mu.Unlock()
+
+		if count > limit {
+			c.Header("Retry-After", strconv.Itoa(int(time.Until(resetAt).Seconds())+1))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "Too many requests, please try again later",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Album struct {
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Title       string         `json:"title"`
+	Slug        string         `json:"slug" gorm:"uniqueIndex:idx_albums_slug,where:deleted_at IS NULL"`
+	Description string         `json:"description"`
+	Image       string         `json:"image"`
+	MediaID     *uint          `json:"media_id"`
+	Media       *Media         `json:"media,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	EventDate   *time.Time     `json:"event_date" gorm:"type:date;index"`
+	SortOrder   int            `json:"sort_order" gorm:"default:0;index"`
+	Photos      []Photo        `json:"photos,omitempty" gorm:"foreignKey:AlbumID;constraint:OnDelete:SET NULL"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
+}
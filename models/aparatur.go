@@ -1,13 +1,20 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Aparatur struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Image       string    `json:"image"`
-	Name        string    `json:"name"`
-	Position    string    `json:"position"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Image       string         `json:"image"`
+	MediaID     *uint          `json:"media_id"`
+	Media       *Media         `json:"media,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	Name        string         `json:"name"`
+	Position    string         `json:"position"`
+	Description string         `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
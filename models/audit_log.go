@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type AuditLog struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	UserID     *uint     `json:"user_id" gorm:"index"`
+	Username   string    `json:"username" gorm:"index"`
+	Action     string    `json:"action" gorm:"index"`
+	EntityType string    `json:"entity_type" gorm:"index:idx_audit_logs_entity"`
+	EntityID   uint      `json:"entity_id" gorm:"index:idx_audit_logs_entity"`
+	Before     string    `json:"before" gorm:"type:jsonb"`
+	After      string    `json:"after" gorm:"type:jsonb"`
+	Changes    string    `json:"changes" gorm:"type:jsonb"`
+	IPAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	CommentStatusPending  = "pending"
+	CommentStatusApproved = "approved"
+	CommentStatusSpam     = "spam"
+)
+
+type Comment struct {
+	ID         uint       `json:"id" gorm:"primaryKey"`
+	PostID     uint       `json:"post_id" gorm:"index"`
+	Post       Post       `json:"post" gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE"`
+	ParentID   *uint      `json:"parent_id" gorm:"index"`
+	Replies    []Comment  `json:"replies" gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE"`
+	Name       string     `json:"name"`
+	Email      string     `json:"email"`
+	Content    string     `json:"content" gorm:"type:text"`
+	Status     string     `json:"status" gorm:"default:pending;index"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	ApprovedAt *time.Time `json:"approved_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
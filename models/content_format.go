@@ -0,0 +1,6 @@
+package models
+
+const (
+	ContentFormatHTML     = "html"
+	ContentFormatMarkdown = "markdown"
+)
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+type LoginAttempt struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	Scope        string     `json:"scope" gorm:"uniqueIndex:idx_login_attempt_key;not null"`
+	Identifier   string     `json:"identifier" gorm:"uniqueIndex:idx_login_attempt_key;not null"`
+	Username     string     `json:"username" gorm:"index"`
+	Failures     int        `json:"failures"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
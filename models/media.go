@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+const (
+	MediaTypeImage    = "image"
+	MediaTypeVideo    = "video"
+	MediaTypeDocument = "document"
+)
+
+type Media struct {
+	ID           uint         `json:"id" gorm:"primaryKey"`
+	FileName     string       `json:"file_name" gorm:"uniqueIndex"`
+	OriginalName string       `json:"original_name"`
+	MimeType     string       `json:"mime_type"`
+	Type         string       `json:"type" gorm:"index"`
+	Size         int64        `json:"size"`
+	Width        int          `json:"width"`
+	Height       int          `json:"height"`
+	AltText      string       `json:"alt_text"`
+	UserID       uint         `json:"user_id"`
+	User         User         `json:"user" gorm:"foreignKey:UserID"`
+	Usages       []MediaUsage `json:"usages" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// MediaUsage records that a piece of content uses a media file, either as
+// its image or embedded in its body.
+type MediaUsage struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	MediaID    uint      `json:"media_id" gorm:"uniqueIndex:idx_media_usages_entity"`
+	EntityType string    `json:"entity_type" gorm:"uniqueIndex:idx_media_usages_entity;index:idx_media_usages_lookup"`
+	EntityID   uint      `json:"entity_id" gorm:"uniqueIndex:idx_media_usages_entity;index:idx_media_usages_lookup"`
+	CreatedAt  time.Time `json:"created_at"`
+}
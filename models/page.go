@@ -1,14 +1,24 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Page struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug" gorm:"unique"`
-	Content   string    `json:"content"`
-	UserID    uint      `json:"user_id"`
-	User      User      `json:"user" gorm:"foreignKey:UserID"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Title         string         `json:"title"`
+	Slug          string         `json:"slug" gorm:"uniqueIndex:idx_pages_slug,where:deleted_at IS NULL"`
+	Content       string         `json:"content"`
+	ContentFormat string         `json:"content_format" gorm:"default:html"`
+	ContentHTML   string         `json:"content_html"`
+	MediaID       *uint          `json:"media_id"`
+	Media         *Media         `json:"media,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	SEO           SEO            `json:"seo" gorm:"embedded"`
+	UserID        uint           `json:"user_id"`
+	User          User           `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
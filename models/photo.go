@@ -1,12 +1,19 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Photo struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Image       string    `json:"image"`
-	Caption     string    `json:"caption"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint           `json:"id" gorm:"primaryKey"`
+	Image       string         `json:"image"`
+	Caption     string         `json:"caption"`
+	Description string         `json:"description"`
+	AlbumID     *uint          `json:"album_id" gorm:"index"`
+	SortOrder   int            `json:"sort_order" gorm:"default:0"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
@@ -1,17 +1,38 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	PostStatusDraft     = "draft"
+	PostStatusReview    = "review"
+	PostStatusPublished = "published"
+	PostStatusArchived  = "archived"
+)
 
 type Post struct {
-	ID         uint      `json:"id" gorm:"primaryKey"`
-	Image      string    `json:"image"`
-	Title      string    `json:"title"`
-	Slug       string    `json:"slug" gorm:"unique"`
-	Content    string    `json:"content"`
-	CategoryID uint      `json:"category_id"`
-	Category   Category    `json:"category" gorm:"foreignKey:CategoryID"`
-	UserID     uint      `json:"user_id"`
-	User       User      `json:"user" gorm:"foreignKey:UserID"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Image         string         `json:"image"`
+	ImageSize     int64          `json:"image_size"`
+	MediaID       *uint          `json:"media_id"`
+	Media         *Media         `json:"media,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	Title         string         `json:"title"`
+	Slug          string         `json:"slug" gorm:"uniqueIndex:idx_posts_slug,where:deleted_at IS NULL"`
+	Content       string         `json:"content"`
+	Status        string         `json:"status" gorm:"default:published;index"`
+	PublishedAt   *time.Time     `json:"published_at" gorm:"index"`
+	CategoryID    uint           `json:"category_id"`
+	Category      Category       `json:"category" gorm:"foreignKey:CategoryID"`
+	UserID        uint           `json:"user_id"`
+	User          User           `json:"user" gorm:"foreignKey:UserID"`
+	ContentFormat string         `json:"content_format" gorm:"default:html"`
+	ContentHTML   string         `json:"content_html"`
+	SEO           SEO            `json:"seo" gorm:"embedded"`
+	Tags          []Tag          `json:"tags" gorm:"many2many:post_tags;constraint:OnDelete:CASCADE"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
@@ -1,19 +1,27 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Product struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Image     string    `json:"image"`
-	Title     string    `json:"title"`
-	Slug      string    `json:"slug" gorm:"unique"`
-	Content   string    `json:"content"`
-	Owner     string    `json:"owner"`
-	Price     int       `json:"price"`
-	Phone     string    `json:"phone"`
-	Address   string    `json:"address"`
-	UserID    uint      `json:"user_id"`
-	User      User      `json:"user" gorm:"foreignKey:UserID"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	Image     string         `json:"image"`
+	MediaID   *uint          `json:"media_id"`
+	Media     *Media         `json:"media,omitempty" gorm:"foreignKey:MediaID;constraint:OnDelete:RESTRICT"`
+	Title     string         `json:"title"`
+	Slug      string         `json:"slug" gorm:"uniqueIndex:idx_products_slug,where:deleted_at IS NULL"`
+	Content   string         `json:"content"`
+	Owner     string         `json:"owner"`
+	Price     int            `json:"price"`
+	Phone     string         `json:"phone"`
+	Address   string         `json:"address"`
+	SEO       SEO            `json:"seo" gorm:"embedded"`
+	UserID    uint           `json:"user_id"`
+	User      User           `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"index"`
 }
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type RefreshToken struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	SessionID uint       `json:"session_id" gorm:"index"`
+	Session   Session    `json:"session" gorm:"foreignKey:SessionID;constraint:OnDelete:CASCADE"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
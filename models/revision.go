@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type Revision struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	EntityType    string    `json:"entity_type" gorm:"index:idx_revisions_entity"`
+	EntityID      uint      `json:"entity_id" gorm:"index:idx_revisions_entity"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	ContentFormat string    `json:"content_format"`
+	Image         string    `json:"image"`
+	MediaID       *uint     `json:"media_id"`
+	CategoryID    *uint     `json:"category_id"`
+	UserID        uint      `json:"user_id"`
+	User          User      `json:"user" gorm:"foreignKey:UserID"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+// SEO holds the optional search and share metadata of a content item.
+// Empty fields fall back to values derived from the content itself.
+type SEO struct {
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+	CanonicalURL    string `json:"canonical_url"`
+	OGImage         string `json:"og_image"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Session struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	UUID      string     `json:"uuid" gorm:"uniqueIndex;not null"`
+	UserID    uint       `json:"user_id" gorm:"index"`
+	User      User       `json:"user" gorm:"foreignKey:UserID"`
+	IPAddress string     `json:"ip_address"`
+	UserAgent string     `json:"user_agent"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
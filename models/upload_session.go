@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	UploadSessionStatusPending   = "pending"
+	UploadSessionStatusCompleted = "completed"
+	// UploadSessionStatusClaimed marks an upload a handler is storing.
+	UploadSessionStatusClaimed = "claimed"
+)
+
+// UploadSession is a resumable upload sent in chunks. The data is
+// assembled on the local disk until a create or update handler takes it
+// over by ID.
+type UploadSession struct {
+	ID          string     `json:"id" gorm:"primaryKey;size:36"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Offset      int64      `json:"offset"`
+	Checksum    string     `json:"checksum"`
+	Status      string     `json:"status" gorm:"default:pending;index"`
+	UserID      uint       `json:"user_id" gorm:"index"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"index"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
@@ -3,12 +3,16 @@ package models
 import "time"
 
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Name      string    `json:"name"`
-	Username  string    `json:"username" gorm:"unique;not null"`
-	Email     string    `json:"email" gorm:"unique;not null"`
-	Password  string    `json:"-"`
-	Roles     []Role    `json:"roles" gorm:"many2many:user_roles"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                   uint       `json:"id" gorm:"primaryKey"`
+	Name                 string     `json:"name"`
+	Username             string     `json:"username" gorm:"unique;not null"`
+	Email                string     `json:"email" gorm:"unique;not null"`
+	Password             string     `json:"-"`
+	TwoFactorSecret      string     `json:"-"`
+	TwoFactorEnabled     bool       `json:"two_factor_enabled" gorm:"default:false"`
+	TwoFactorConfirmedAt *time.Time `json:"two_factor_confirmed_at"`
+	TwoFactorLastStep    int64      `json:"-"`
+	Roles                []Role     `json:"roles" gorm:"many2many:user_roles"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
 }
@@ -1,26 +1,39 @@
 package routes
 
 import (
+	"log"
+	"time"
+
 	adminController "github.com/ahmadalaik/desa-digital/controllers/admin"
 	authController "github.com/ahmadalaik/desa-digital/controllers/auth"
 	publicController "github.com/ahmadalaik/desa-digital/controllers/public"
+	"github.com/ahmadalaik/desa-digital/helpers"
 	"github.com/ahmadalaik/desa-digital/middlewares"
+	"github.com/ahmadalaik/desa-digital/storage"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
 func SetupRouter() *gin.Engine {
 	router := gin.Default()
+	if err := router.SetTrustedProxies(helpers.TrustedProxies()); err != nil {
+		log.Fatal("Invalid TRUSTED_PROXIES: ", err)
+	}
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins:  []string{"*"},
-		AllowMethods:  []string{"GET", "POST", "PUST", "DELETE", "OPTIONS"},
-		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization"},
-		ExposeHeaders: []string{"Content-Length"},
+		AllowMethods:  []string{"GET", "POST", "PUST", "DELETE", "PATCH", "HEAD", "OPTIONS"},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Authorization", "Upload-Offset", "Upload-Length", "Upload-Checksum"},
+		ExposeHeaders: []string{"Content-Length", "Location", "Upload-Offset", "Upload-Length"},
 	}))
 
 	auth := router.Group("/api")
 	auth.POST("/login", authController.Login)
+	auth.POST("/login/two-factor", authController.LoginTwoFactor)
+	auth.POST("/refresh", authController.Refresh)
+	auth.POST("/logout", middlewares.AuthMiddleware(), authController.Logout)
+	auth.POST("/password/forgot", authController.ForgotPassword)
+	auth.POST("/password/reset", authController.ResetPassword)
 
 	// require authentication
 	protected := router.Group("/api/admin")
@@ -28,6 +41,15 @@ func SetupRouter() *gin.Engine {
 	// param1 url, param2 middleware checks whether the user has permission, param3 function (controller)
 	protected.GET("/dashboard", middlewares.Permission("dashboard-index"), adminController.Dashboard)
 
+	// two-factor routes, available to every signed in user so they can enrol
+	protected.GET("/two-factor", adminController.FindTwoFactor)
+	protected.POST("/two-factor/enable", adminController.EnableTwoFactor)
+	protected.POST("/two-factor/confirm", adminController.ConfirmTwoFactor)
+	protected.POST("/two-factor/recovery-codes", adminController.RegenerateRecoveryCodes)
+	protected.POST("/two-factor/disable", adminController.DisableTwoFactor)
+	protected.GET("/settings/two-factor", middlewares.Permission("settings-index"), adminController.FindTwoFactorPolicy)
+	protected.PUT("/settings/two-factor", middlewares.Permission("settings-update"), adminController.UpdateTwoFactorPolicy)
+
 	protected.GET("/permissions", middlewares.Permission("permissions-index"), adminController.FindPermissons)
 	protected.POST("/permissions", middlewares.Permission("permissions-create"), adminController.CreatePermission)
 	protected.GET("/permissions/:id", middlewares.Permission("permissions-show"), adminController.FindPermissonByID)
@@ -49,6 +71,7 @@ func SetupRouter() *gin.Engine {
 	protected.GET("/users/:id", middlewares.Permission("users-show"), adminController.FindUserByID)
 	protected.PUT("/users/:id", middlewares.Permission("users-Update"), adminController.UpdateUser)
 	protected.DELETE("/users/:id", middlewares.Permission("users-delete"), adminController.DeleteUser)
+	protected.POST("/users/:id/unlock", middlewares.Permission("users-update"), adminController.UnlockUser)
 
 	// category routes
 	protected.GET("/categories", middlewares.Permission("categories-index"), adminController.FindCategories)
@@ -58,12 +81,28 @@ func SetupRouter() *gin.Engine {
 	protected.DELETE("/categories/:id", middlewares.Permission("categories-delete"), adminController.DeleteCategory)
 	protected.GET("/categories/all", middlewares.Permission("categories-index"), adminController.FindAllCategories)
 
+	// tag routes
+	protected.GET("/tags", middlewares.Permission("tags-index"), adminController.FindTags)
+	protected.POST("/tags", middlewares.Permission("tags-create"), adminController.CreateTag)
+	protected.GET("/tags/:id", middlewares.Permission("tags-show"), adminController.FindTagByID)
+	protected.PUT("/tags/:id", middlewares.Permission("tags-update"), adminController.UpdateTag)
+	protected.DELETE("/tags/:id", middlewares.Permission("tags-delete"), adminController.DeleteTag)
+	protected.GET("/tags/all", middlewares.Permission("tags-index"), adminController.FindAllTags)
+
 	// post routes
 	protected.GET("/posts", middlewares.Permission("posts-index"), adminController.FindPosts)
 	protected.POST("/posts", middlewares.Permission("posts-create"), adminController.CreatePost)
 	protected.GET("/posts/:id", middlewares.Permission("posts-show"), adminController.FindPostByID)
 	protected.PUT("/posts/:id", middlewares.Permission("posts-update"), adminController.UpdatePost)
 	protected.DELETE("/posts/:id", middlewares.Permission("posts-delete"), adminController.DeletPost)
+	protected.GET("/posts/trash", middlewares.Permission("posts-delete"), adminController.FindTrash("posts"))
+	protected.POST("/posts/:id/restore", middlewares.Permission("posts-delete"), adminController.RestoreTrash("posts"))
+	protected.DELETE("/posts/:id/purge", middlewares.Permission("posts-delete"), adminController.PurgeTrash("posts"))
+	protected.POST("/posts/bulk", middlewares.Permission("posts-index"), adminController.BulkAction("posts"))
+	protected.GET("/posts/:id/revisions", middlewares.Permission("posts-show"), adminController.FindPostRevisions)
+	protected.GET("/posts/:id/revisions/diff", middlewares.Permission("posts-show"), adminController.DiffPostRevisions)
+	protected.GET("/posts/:id/revisions/:revision_id", middlewares.Permission("posts-show"), adminController.FindPostRevisionByID)
+	protected.POST("/posts/:id/revisions/:revision_id/restore", middlewares.Permission("posts-update"), adminController.RestorePostRevision)
 
 	// page routes
 	protected.GET("/pages", middlewares.Permission("pages-index"), adminController.FindPages)
@@ -71,6 +110,14 @@ func SetupRouter() *gin.Engine {
 	protected.GET("/pages/:id", middlewares.Permission("pages-show"), adminController.FindPageByID)
 	protected.PUT("/pages/:id", middlewares.Permission("pages-update"), adminController.UpdatePage)
 	protected.DELETE("/pages/:id", middlewares.Permission("pages-delete"), adminController.DeletePage)
+	protected.GET("/pages/trash", middlewares.Permission("pages-delete"), adminController.FindTrash("pages"))
+	protected.POST("/pages/:id/restore", middlewares.Permission("pages-delete"), adminController.RestoreTrash("pages"))
+	protected.DELETE("/pages/:id/purge", middlewares.Permission("pages-delete"), adminController.PurgeTrash("pages"))
+	protected.POST("/pages/bulk", middlewares.Permission("pages-index"), adminController.BulkAction("pages"))
+	protected.GET("/pages/:id/revisions", middlewares.Permission("pages-show"), adminController.FindPageRevisions)
+	protected.GET("/pages/:id/revisions/diff", middlewares.Permission("pages-show"), adminController.DiffPageRevisions)
+	protected.GET("/pages/:id/revisions/:revision_id", middlewares.Permission("pages-show"), adminController.FindPageRevisionByID)
+	protected.POST("/pages/:id/revisions/:revision_id/restore", middlewares.Permission("pages-update"), adminController.RestorePageRevision)
 
 	// product routes
 	protected.GET("/products", middlewares.Permission("products-index"), adminController.FindProducts)
@@ -78,16 +125,68 @@ func SetupRouter() *gin.Engine {
 	protected.GET("/products/:id", middlewares.Permission("products-show"), adminController.FindProductByID)
 	protected.PUT("/products/:id", middlewares.Permission("products-update"), adminController.UpdateProduct)
 	protected.DELETE("/products/:id", middlewares.Permission("products-delete"), adminController.DeleteProduct)
+	protected.GET("/products/trash", middlewares.Permission("products-delete"), adminController.FindTrash("products"))
+	protected.POST("/products/:id/restore", middlewares.Permission("products-delete"), adminController.RestoreTrash("products"))
+	protected.DELETE("/products/:id/purge", middlewares.Permission("products-delete"), adminController.PurgeTrash("products"))
+	protected.POST("/products/bulk", middlewares.Permission("products-index"), adminController.BulkAction("products"))
 
 	// photo routes
 	protected.GET("/photos", middlewares.Permission("photos-index"), adminController.FindPhotos)
 	protected.POST("/photos", middlewares.Permission("photos-create"), adminController.CreatePhoto)
+	protected.PUT("/photos/:id", middlewares.Permission("photos-update"), adminController.UpdatePhoto)
 	protected.DELETE("/photos/:id", middlewares.Permission("photos-delete"), adminController.DeletePhoto)
+	protected.GET("/photos/trash", middlewares.Permission("photos-delete"), adminController.FindTrash("photos"))
+	protected.POST("/photos/:id/restore", middlewares.Permission("photos-delete"), adminController.RestoreTrash("photos"))
+	protected.DELETE("/photos/:id/purge", middlewares.Permission("photos-delete"), adminController.PurgeTrash("photos"))
+	protected.POST("/photos/bulk", middlewares.Permission("photos-index"), adminController.BulkAction("photos"))
+
+	// album routes
+	protected.GET("/albums", middlewares.Permission("albums-index"), adminController.FindAlbums)
+	protected.POST("/albums", middlewares.Permission("albums-create"), adminController.CreateAlbum)
+	protected.GET("/albums/:id", middlewares.Permission("albums-show"), adminController.FindAlbumByID)
+	protected.PUT("/albums/:id", middlewares.Permission("albums-update"), adminController.UpdateAlbum)
+	protected.DELETE("/albums/:id", middlewares.Permission("albums-delete"), adminController.DeleteAlbum)
+	protected.GET("/albums/trash", middlewares.Permission("albums-delete"), adminController.FindTrash("albums"))
+	protected.POST("/albums/:id/restore", middlewares.Permission("albums-delete"), adminController.RestoreTrash("albums"))
+	protected.DELETE("/albums/:id/purge", middlewares.Permission("albums-delete"), adminController.PurgeTrash("albums"))
+	protected.POST("/albums/:id/photos", middlewares.Permission("photos-create"), adminController.UploadAlbumPhotos)
 
 	// slider routes
 	protected.GET("/sliders", middlewares.Permission("sliders-index"), adminController.FindSliders)
 	protected.POST("/sliders", middlewares.Permission("sliders-create"), adminController.CreateSlider)
 	protected.DELETE("/sliders/:id", middlewares.Permission("sliders-delete"), adminController.DeleteSlider)
+	protected.GET("/sliders/trash", middlewares.Permission("sliders-delete"), adminController.FindTrash("sliders"))
+	protected.POST("/sliders/:id/restore", middlewares.Permission("sliders-delete"), adminController.RestoreTrash("sliders"))
+	protected.DELETE("/sliders/:id/purge", middlewares.Permission("sliders-delete"), adminController.PurgeTrash("sliders"))
+	protected.POST("/sliders/bulk", middlewares.Permission("sliders-index"), adminController.BulkAction("sliders"))
+
+	// resumable upload routes, a finished upload is handed to a create or
+	// update handler as "<field>_upload_id" which checks the permission
+	// for the content it is used for
+	protected.POST("/uploads", middlewares.Permission("uploads-create"), adminController.CreateUpload)
+	protected.GET("/uploads/:id", middlewares.Permission("uploads-create"), adminController.FindUpload)
+	protected.HEAD("/uploads/:id", middlewares.Permission("uploads-create"), adminController.FindUpload)
+	protected.PATCH("/uploads/:id", middlewares.Permission("uploads-create"), adminController.PatchUpload)
+	protected.DELETE("/uploads/:id", middlewares.Permission("uploads-create"), adminController.DeleteUpload)
+
+	// media routes
+	protected.GET("/media", middlewares.Permission("media-index"), adminController.FindMedia)
+	protected.POST("/media", middlewares.Permission("media-create"), adminController.CreateMedia)
+	protected.GET("/media/:id", middlewares.Permission("media-show"), adminController.FindMediaByID)
+	protected.PUT("/media/:id", middlewares.Permission("media-update"), adminController.UpdateMedia)
+	protected.DELETE("/media/:id", middlewares.Permission("media-delete"), adminController.DeleteMedia)
+
+	// comment routes
+	protected.GET("/comments", middlewares.Permission("comments-index"), adminController.FindComments)
+	protected.GET("/comments/:id", middlewares.Permission("comments-show"), adminController.FindCommentByID)
+	protected.PUT("/comments/:id/status", middlewares.Permission("comments-update"), adminController.UpdateCommentStatus)
+	protected.DELETE("/comments/:id", middlewares.Permission("comments-delete"), adminController.DeleteComment)
+
+	// compare upload directories with the database
+	protected.POST("/storage/reconcile", middlewares.Permission("storage-reconcile"), adminController.ReconcileUploads)
+
+	// audit log routes
+	protected.GET("/audit-logs", middlewares.Permission("audit-index"), adminController.FindAuditLogs)
 
 	// aparatur routes
 	protected.GET("/aparaturs", middlewares.Permission("aparaturs-index"), adminController.FindAparaturs)
@@ -95,14 +194,24 @@ func SetupRouter() *gin.Engine {
 	protected.GET("/aparaturs/:id", middlewares.Permission("aparaturs-show"), adminController.FindAparaturByID)
 	protected.PUT("/aparaturs/:id", middlewares.Permission("aparaturs-update"), adminController.UpdateAparatur)
 	protected.DELETE("/aparaturs/:id", middlewares.Permission("aparaturs-delete"), adminController.DeleteAparatur)
+	protected.GET("/aparaturs/trash", middlewares.Permission("aparaturs-delete"), adminController.FindTrash("aparaturs"))
+	protected.POST("/aparaturs/:id/restore", middlewares.Permission("aparaturs-delete"), adminController.RestoreTrash("aparaturs"))
+	protected.DELETE("/aparaturs/:id/purge", middlewares.Permission("aparaturs-delete"), adminController.PurgeTrash("aparaturs"))
+	protected.POST("/aparaturs/bulk", middlewares.Permission("aparaturs-index"), adminController.BulkAction("aparaturs"))
 
 	// public routes
 	public := router.Group("/api/public")
 
+	// search routes
+	public.GET("/search", publicController.Search)
+
 	// post routes
 	public.GET("/posts", publicController.FindPosts)
 	public.GET("/posts/:slug", publicController.FindPostBySlug)
+	public.POST("/posts/:slug/comments", middlewares.RateLimit(5, time.Minute), publicController.CreateComment)
 	public.GET("/posts-home", publicController.FindPostsHome)
+	public.GET("/tags", publicController.FindTags)
+	public.GET("/tags/:slug/posts", publicController.FindPostsByTag)
 
 	// page routes
 	public.GET("/pages", publicController.FindPages)
@@ -117,6 +226,10 @@ func SetupRouter() *gin.Engine {
 	public.GET("/photos", publicController.FindPhotos)
 	public.GET("/photos-home", publicController.FindPhotosHome)
 
+	// album routes
+	public.GET("/albums", publicController.FindAlbums)
+	public.GET("/albums/:slug", publicController.FindAlbumBySlug)
+
 	// slider routes
 	public.GET("/sliders", publicController.FindSliders)
 
@@ -125,8 +238,24 @@ func SetupRouter() *gin.Engine {
 	public.GET("/aparaturs/:id", publicController.FindAparaturByID)
 	public.GET("/aparaturs-home", publicController.FindAparatursHome)
 
-	// serve static file form public/uploads
-	router.Static("/static", "./public/uploads")
+	// feed routes
+	router.GET("/feed/posts.rss", publicController.PostsRSS)
+	router.GET("/feed/posts.atom", publicController.PostsAtom)
+	router.GET("/feed/categories/:slug/posts.rss", publicController.CategoryPostsRSS)
+	router.GET("/feed/categories/:slug/posts.atom", publicController.CategoryPostsAtom)
+
+	// sitemap routes
+	router.GET("/sitemap.xml", publicController.Sitemap)
+	router.GET("/sitemaps/:file", publicController.SitemapChunk)
+	router.GET("/robots.txt", publicController.Robots)
+
+	// serve uploads from the local disk, other drivers hand out their own
+	// URLs but old /static links are still streamed through the app
+	if local, ok := storage.Default.(*storage.Local); ok {
+		router.Static("/static", local.Root)
+	} else {
+		router.GET("/static/*filepath", publicController.ServeUpload)
+	}
 
 	return router
 }
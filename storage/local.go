@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type Local struct {
+	Root    string
+	BaseURL string
+}
+
+func NewLocal(root, baseURL string) *Local {
+	return &Local{Root: root, BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (l *Local) path(key string) string {
+	return filepath.Join(l.Root, filepath.FromSlash(cleanKey(key)))
+}
+
+// Put writes to a temporary file first, so readers never see half an
+// upload.
+func (l *Local) Put(key string, r io.Reader, size int64, contentType string) error {
+	target := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), target)
+}
+
+func (l *Local) Get(key string) (io.ReadCloser, error) {
+	file, err := os.Open(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotExist
+	}
+	return file, err
+}
+
+func (l *Local) Delete(key string) error {
+	err := os.Remove(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotExist
+	}
+	return err
+}
+
+func (l *Local) Stat(key string) (Object, error) {
+	info, err := os.Stat(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && info.IsDir()) {
+		return Object{}, ErrNotExist
+	}
+	if err != nil {
+		return Object{}, err
+	}
+	return Object{Key: cleanKey(key), Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// List walks the directory the prefix lives in and keeps the files whose
+// key starts with it.
+func (l *Local) List(prefix string) ([]Object, error) {
+	dir := path.Dir(cleanKey(prefix + "x"))
+	if dir == "." {
+		dir = ""
+	}
+
+	objects := []Object{}
+	err := filepath.WalkDir(l.path(dir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.Root, p)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, Object{Key: key, Size: info.Size(), ModTime: info.ModTime()})
+		return nil
+	})
+	return objects, err
+}
+
+func (l *Local) URL(key string) string {
+	return l.BaseURL + "/" + cleanKey(key)
+}
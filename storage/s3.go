@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+var s3EmptyPayload = hex.EncodeToString(sha256.New().Sum(nil))
+
+type S3Config struct {
+	// Endpoint is the scheme and host of the service, e.g.
+	// https://s3.ap-southeast-1.amazonaws.com or http://localhost:9000
+	// for MinIO.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	// PublicURL is where objects are downloaded from, such as a CDN. It
+	// defaults to the bucket on the endpoint.
+	PublicURL string
+}
+
+// S3 talks to any S3 compatible service with path style addressing and
+// Signature Version 4.
+type S3 struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+type s3ListResult struct {
+	Contents []struct {
+		Key          string `xml:"Key"`
+		Size         int64  `xml:"Size"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+func NewS3(config S3Config) *S3 {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	config.PublicURL = strings.TrimRight(config.PublicURL, "/")
+	if config.PublicURL == "" {
+		config.PublicURL = config.Endpoint + "/" + config.Bucket
+	}
+	return &S3{config: config, client: &http.Client{Timeout: 5 * time.Minute}, now: time.Now}
+}
+
+func (s *S3) Put(key string, r io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(http.MethodPut, key, nil, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req, s3UnsignedPayload)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3) Get(key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete reports ErrNotExist like the local driver, S3 itself answers 204
+// for missing keys too.
+func (s *S3) Delete(key string) error {
+	if _, err := s.Stat(key); err != nil {
+		return err
+	}
+
+	req, err := s.newRequest(http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3) Stat(key string) (Object, error) {
+	req, err := s.newRequest(http.MethodHead, key, nil, nil)
+	if err != nil {
+		return Object{}, err
+	}
+
+	resp, err := s.do(req, s3EmptyPayload)
+	if err != nil {
+		return Object{}, err
+	}
+	resp.Body.Close()
+
+	modTime, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	size, _ := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	return Object{Key: cleanKey(key), Size: size, ModTime: modTime}, nil
+}
+
+func (s *S3) List(prefix string) ([]Object, error) {
+	objects := []Object{}
+	token := ""
+
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := s.newRequest(http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.do(req, s3EmptyPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, content := range result.Contents {
+			modTime, _ := time.Parse(time.RFC3339, content.LastModified)
+			objects = append(objects, Object{Key: content.Key, Size: content.Size, ModTime: modTime})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+func (s *S3) URL(key string) string {
+	return s.config.PublicURL + "/" + s3EscapePath(cleanKey(key))
+}
+
+func (s *S3) newRequest(method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	path := "/" + s.config.Bucket
+	if key != "" {
+		path += "/" + cleanKey(key)
+	}
+
+	rawURL := s.config.Endpoint + s3EscapePath(path)
+	if len(query) > 0 {
+		rawURL += "?" + s3CanonicalQuery(query)
+	}
+	return http.NewRequest(method, rawURL, body)
+}
+
+// do signs and sends the request. Responses other than 2xx are turned into
+// errors, 404 into ErrNotExist.
+func (s *S3) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	s.sign(req, payloadHash, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotExist
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("storage: s3 %s %s: %s %s", req.Method, req.URL.Path, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// sign adds a Signature Version 4 Authorization header covering the host,
+// the content type and every x-amz-* header.
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest, signedHeaders := s3CanonicalRequest(req, payloadHash)
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hash[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey, scope, signedHeaders, signature))
+}
+
+// s3CanonicalRequest returns the canonical request of a signed request and
+// the list of headers it covers.
+func s3CanonicalRequest(req *http.Request, payloadHash string) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" || lower == "range" {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	return canonicalRequest, signedHeaders
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3Escape percent-encodes everything except the unreserved characters of
+// RFC 3986, as Signature Version 4 requires.
+func s3Escape(value string) string {
+	var b strings.Builder
+	for _, c := range []byte(value) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func s3CanonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := []string{}
+	for _, key := range keys {
+		values := append([]string{}, query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(key)+"="+s3Escape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ahmadalaik/desa-digital/config"
+)
+
+var ErrNotExist = errors.New("storage: object does not exist")
+
+type Object struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage keeps uploaded files under slash separated keys such as
+// "posts/<uuid>.jpg", the first segment being the upload directory.
+type Storage interface {
+	Put(key string, r io.Reader, size int64, contentType string) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+	Stat(key string) (Object, error)
+	List(prefix string) ([]Object, error)
+	// URL is where the object can be downloaded, either absolute or a
+	// path relative to the site.
+	URL(key string) string
+}
+
+// Default is the local disk until Init picks the configured driver.
+var Default Storage = NewLocal("public/uploads", "/static")
+
+func Init() {
+	publicURL := config.GetEnv("STORAGE_PUBLIC_URL", "")
+
+	switch driver := config.GetEnv("STORAGE_DRIVER", "local"); driver {
+	case "local":
+		if publicURL == "" {
+			publicURL = "/static"
+		}
+		Default = NewLocal(config.GetEnv("STORAGE_LOCAL_ROOT", "public/uploads"), publicURL)
+	case "s3":
+		Default = NewS3(S3Config{
+			Endpoint:  config.GetEnv("STORAGE_S3_ENDPOINT", ""),
+			Region:    config.GetEnv("STORAGE_S3_REGION", "us-east-1"),
+			Bucket:    config.GetEnv("STORAGE_S3_BUCKET", ""),
+			AccessKey: config.GetEnv("STORAGE_S3_ACCESS_KEY", ""),
+			SecretKey: config.GetEnv("STORAGE_S3_SECRET_KEY", ""),
+			PublicURL: publicURL,
+		})
+	default:
+		log.Fatalf("unknown STORAGE_DRIVER %q", driver)
+	}
+}
+
+func Key(dir, fileName string) string {
+	return dir + "/" + fileName
+}
+
+func Exists(s Storage, key string) (bool, error) {
+	_, err := s.Stat(key)
+	if errors.Is(err, ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// cleanKey drops leading slashes and any "..", so a key can never point
+// outside the storage root.
+func cleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
@@ -0,0 +1,46 @@
+package structs
+
+type (
+	AlbumCreateRequest struct {
+		Title       string `json:"title" form:"title" binding:"required"`
+		Description string `json:"description" form:"description"`
+		EventDate   string `json:"event_date" form:"event_date" binding:"omitempty,datetime=2006-01-02"`
+		SortOrder   int    `json:"sort_order" form:"sort_order"`
+		MediaID     *uint  `json:"media_id" form:"media_id"`
+	}
+
+	AlbumUpdateRequest struct {
+		Title       string `json:"title" form:"title" binding:"required"`
+		Description string `json:"description" form:"description"`
+		EventDate   string `json:"event_date" form:"event_date" binding:"omitempty,datetime=2006-01-02"`
+		SortOrder   int    `json:"sort_order" form:"sort_order"`
+		MediaID     *uint  `json:"media_id" form:"media_id"`
+	}
+)
+
+type (
+	AlbumResponse struct {
+		ID          uint                 `json:"id"`
+		Title       string               `json:"title"`
+		Slug        string               `json:"slug"`
+		Description string               `json:"description"`
+		Image       string               `json:"image"`
+		Cover       *ImagesResponse      `json:"cover,omitempty"`
+		Media       *MediaSimpleResponse `json:"media,omitempty"`
+		EventDate   string               `json:"event_date"`
+		SortOrder   int                  `json:"sort_order"`
+		PhotosCount int64                `json:"photos_count"`
+		CreatedAt   string               `json:"created_at"`
+		UpdatedAt   string               `json:"updated_at"`
+	}
+
+	AlbumWithPhotosResponse struct {
+		AlbumResponse
+		Photos any `json:"photos"`
+	}
+
+	AlbumUploadResponse struct {
+		Photos []PhotoResponse   `json:"photos"`
+		Failed map[string]string `json:"failed,omitempty"`
+	}
+)
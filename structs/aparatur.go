@@ -2,12 +2,14 @@ package structs
 
 type (
 	AparaturCreateRequest struct {
+		MediaID     *uint  `json:"media_id" form:"media_id"`
 		Name        string `json:"name" binding:"required"`
 		Position    string `json:"position" binding:"required"`
 		Description string `json:"description" binding:"required"`
 	}
 
 	AparaturUpdateRequest struct {
+		MediaID     *uint  `json:"media_id" form:"media_id"`
 		Name        string `json:"name" binding:"required"`
 		Position    string `json:"position" binding:"required"`
 		Description string `json:"description" binding:"required"`
@@ -16,12 +18,14 @@ type (
 
 type (
 	AparaturResponse struct {
-		ID          uint   `json:"id"`
-		Image       string `json:"image"`
-		Name        string `json:"name"`
-		Position    string `json:"position"`
-		Description string `json:"description"`
-		CreatedAt   string `json:"created_at"`
-		UpdatedAt   string `json:"updated_at"`
+		ID          uint                 `json:"id"`
+		Image       string               `json:"image"`
+		Images      *ImagesResponse      `json:"images,omitempty"`
+		Media       *MediaSimpleResponse `json:"media,omitempty"`
+		Name        string               `json:"name"`
+		Position    string               `json:"position"`
+		Description string               `json:"description"`
+		CreatedAt   string               `json:"created_at"`
+		UpdatedAt   string               `json:"updated_at"`
 	}
 )
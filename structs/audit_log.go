@@ -0,0 +1,20 @@
+package structs
+
+import "encoding/json"
+
+type (
+	AuditLogResponse struct {
+		ID         uint            `json:"id"`
+		UserID     *uint           `json:"user_id"`
+		Username   string          `json:"username"`
+		Action     string          `json:"action"`
+		EntityType string          `json:"entity_type"`
+		EntityID   uint            `json:"entity_id"`
+		Before     json.RawMessage `json:"before"`
+		After      json.RawMessage `json:"after"`
+		Changes    json.RawMessage `json:"changes"`
+		IPAddress  string          `json:"ip_address"`
+		UserAgent  string          `json:"user_agent"`
+		CreatedAt  string          `json:"created_at"`
+	}
+)
@@ -0,0 +1,27 @@
+package structs
+
+type (
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+
+	ForgotPasswordRequest struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	ResetPasswordRequest struct {
+		Token                string `json:"token" binding:"required"`
+		Password             string `json:"password" binding:"required,min=8"`
+		PasswordConfirmation string `json:"password_confirmation" binding:"required,eqfield=Password"`
+	}
+)
+
+type (
+	TokenResponse struct {
+		Token            string `json:"token"`
+		RefreshToken     string `json:"refresh_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresAt        string `json:"expires_at"`
+		RefreshExpiresAt string `json:"refresh_expires_at"`
+	}
+)
@@ -0,0 +1,27 @@
+package structs
+
+type (
+	BulkActionRequest struct {
+		IDs    []uint `json:"ids" binding:"required,min=1,max=100"`
+		Action string `json:"action" binding:"required,oneof=delete publish unpublish change_category reassign_author"`
+		// CategoryID is the target of change_category, UserID the new
+		// author for reassign_author.
+		CategoryID uint `json:"category_id"`
+		UserID     uint `json:"user_id"`
+	}
+)
+
+type (
+	BulkItemResponse struct {
+		ID      uint   `json:"id"`
+		Success bool   `json:"success"`
+		Error   string `json:"error,omitempty"`
+	}
+
+	BulkActionResponse struct {
+		Action    string             `json:"action"`
+		Succeeded int                `json:"succeeded"`
+		Failed    int                `json:"failed"`
+		Results   []BulkItemResponse `json:"results"`
+	}
+)
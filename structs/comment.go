@@ -0,0 +1,42 @@
+package structs
+
+type (
+	CommentCreateRequest struct {
+		Name     string `json:"name" binding:"required,max=100"`
+		Email    string `json:"email" binding:"omitempty,email"`
+		Content  string `json:"content" binding:"required,max=2000"`
+		ParentID *uint  `json:"parent_id"`
+		// Website is a honeypot, it is hidden in the form so only bots fill it
+		Website string `json:"website"`
+	}
+
+	CommentStatusRequest struct {
+		Status string `json:"status" binding:"required,oneof=pending approved spam"`
+	}
+)
+
+type (
+	CommentResponse struct {
+		ID        uint              `json:"id"`
+		ParentID  *uint             `json:"parent_id"`
+		Name      string            `json:"name"`
+		Content   string            `json:"content"`
+		Replies   []CommentResponse `json:"replies"`
+		CreatedAt string            `json:"created_at"`
+	}
+
+	CommentAdminResponse struct {
+		ID         uint               `json:"id"`
+		ParentID   *uint              `json:"parent_id"`
+		Post       PostSimpleResponse `json:"post"`
+		Name       string             `json:"name"`
+		Email      string             `json:"email"`
+		Content    string             `json:"content"`
+		Status     string             `json:"status"`
+		IPAddress  string             `json:"ip_address"`
+		UserAgent  string             `json:"user_agent"`
+		ApprovedAt string             `json:"approved_at"`
+		CreatedAt  string             `json:"created_at"`
+		UpdatedAt  string             `json:"updated_at"`
+	}
+)
@@ -0,0 +1,98 @@
+package structs
+
+import "encoding/xml"
+
+type (
+	RSSFeed struct {
+		XMLName   xml.Name   `xml:"rss"`
+		Version   string     `xml:"version,attr"`
+		ContentNS string     `xml:"xmlns:content,attr"`
+		AtomNS    string     `xml:"xmlns:atom,attr"`
+		Channel   RSSChannel `xml:"channel"`
+	}
+
+	RSSChannel struct {
+		Title         string      `xml:"title"`
+		Link          string      `xml:"link"`
+		Description   string      `xml:"description"`
+		Language      string      `xml:"language"`
+		LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+		AtomLink      RSSAtomLink `xml:"atom:link"`
+		Items         []RSSItem   `xml:"item"`
+	}
+
+	RSSAtomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	}
+
+	RSSItem struct {
+		Title          string        `xml:"title"`
+		Link           string        `xml:"link"`
+		GUID           RSSGUID       `xml:"guid"`
+		Description    string        `xml:"description"`
+		ContentEncoded RSSCDATA      `xml:"content:encoded"`
+		Author         string        `xml:"author,omitempty"`
+		Category       string        `xml:"category,omitempty"`
+		PubDate        string        `xml:"pubDate"`
+		Enclosure      *RSSEnclosure `xml:"enclosure,omitempty"`
+	}
+
+	RSSGUID struct {
+		Value       string `xml:",chardata"`
+		IsPermaLink string `xml:"isPermaLink,attr"`
+	}
+
+	RSSCDATA struct {
+		Value string `xml:",cdata"`
+	}
+
+	RSSEnclosure struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	}
+
+	AtomFeed struct {
+		XMLName xml.Name    `xml:"feed"`
+		XMLNS   string      `xml:"xmlns,attr"`
+		ID      string      `xml:"id"`
+		Title   string      `xml:"title"`
+		Updated string      `xml:"updated"`
+		Links   []AtomLink  `xml:"link"`
+		Entries []AtomEntry `xml:"entry"`
+	}
+
+	AtomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+
+	AtomEntry struct {
+		ID        string        `xml:"id"`
+		Title     string        `xml:"title"`
+		Updated   string        `xml:"updated"`
+		Published string        `xml:"published"`
+		Links     []AtomLink    `xml:"link"`
+		Author    *AtomAuthor   `xml:"author,omitempty"`
+		Category  *AtomCategory `xml:"category,omitempty"`
+		Summary   AtomText      `xml:"summary"`
+		Content   AtomText      `xml:"content"`
+	}
+
+	AtomAuthor struct {
+		Name string `xml:"name"`
+	}
+
+	AtomCategory struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr,omitempty"`
+	}
+
+	AtomText struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+)
@@ -0,0 +1,14 @@
+package structs
+
+type (
+	ImagesResponse struct {
+		Original string                          `json:"original"`
+		Variants map[string]ImageVariantResponse `json:"variants"`
+	}
+
+	ImageVariantResponse struct {
+		URL   string `json:"url"`
+		WebP  string `json:"webp,omitempty"`
+		Width int    `json:"width"`
+	}
+)
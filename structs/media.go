@@ -0,0 +1,43 @@
+package structs
+
+type (
+	MediaCreateRequest struct {
+		AltText string `form:"alt_text" json:"alt_text" binding:"max=255"`
+	}
+
+	MediaUpdateRequest struct {
+		AltText string `json:"alt_text" binding:"max=255"`
+	}
+)
+
+type (
+	MediaUsageResponse struct {
+		EntityType string `json:"entity_type"`
+		EntityID   uint   `json:"entity_id"`
+	}
+
+	MediaResponse struct {
+		ID           uint                 `json:"id"`
+		FileName     string               `json:"file_name"`
+		OriginalName string               `json:"original_name"`
+		MimeType     string               `json:"mime_type"`
+		Type         string               `json:"type"`
+		Size         int64                `json:"size"`
+		Width        int                  `json:"width,omitempty"`
+		Height       int                  `json:"height,omitempty"`
+		AltText      string               `json:"alt_text"`
+		URL          string               `json:"url"`
+		Images       *ImagesResponse      `json:"images,omitempty"`
+		UsageCount   int64                `json:"usage_count"`
+		Usages       []MediaUsageResponse `json:"usages,omitempty"`
+		User         UserSimpleResponse   `json:"user"`
+		CreatedAt    string               `json:"created_at"`
+		UpdatedAt    string               `json:"updated_at"`
+	}
+
+	MediaSimpleResponse struct {
+		ID      uint   `json:"id"`
+		URL     string `json:"url"`
+		AltText string `json:"alt_text"`
+	}
+)
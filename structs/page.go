@@ -2,34 +2,52 @@ package structs
 
 type (
 	PageCreateRequest struct {
-		Title   string `json:"title" binding:"required"`
-		Content string `json:"content" binding:"required"`
+		SEORequest
+
+		MediaID       *uint  `json:"media_id" form:"media_id"`
+		Title         string `json:"title" binding:"required"`
+		Content       string `json:"content" binding:"required"`
+		ContentFormat string `json:"content_format" binding:"omitempty,oneof=html markdown"`
 	}
 
 	PageUpdateRequest struct {
-		Title   string `json:"title" binding:"required"`
-		Content string `json:"content" binding:"required"`
+		SEORequest
+
+		MediaID       *uint  `json:"media_id" form:"media_id"`
+		Title         string `json:"title" binding:"required"`
+		Content       string `json:"content" binding:"required"`
+		ContentFormat string `json:"content_format" binding:"omitempty,oneof=html markdown"`
 	}
 )
 
 type (
 	PageResponse struct {
-		ID        uint   `json:"id"`
-		Title     string `json:"title"`
-		Slug      string `json:"slug"`
-		Content   string `json:"content"`
-		UserID    uint   `json:"user_id"`
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ID            uint                 `json:"id"`
+		Title         string               `json:"title"`
+		Slug          string               `json:"slug"`
+		Content       string               `json:"content"`
+		ContentFormat string               `json:"content_format"`
+		ContentHTML   string               `json:"content_html"`
+		Images        *ImagesResponse      `json:"images,omitempty"`
+		Media         *MediaSimpleResponse `json:"media,omitempty"`
+		UserID        uint                 `json:"user_id"`
+		SEO           *SEOResponse         `json:"seo,omitempty"`
+		CreatedAt     string               `json:"created_at"`
+		UpdatedAt     string               `json:"updated_at"`
 	}
 
 	PageWithRelationResponse struct {
-		ID        uint               `json:"id"`
-		Title     string             `json:"title"`
-		Slug      string             `json:"slug"`
-		Content   string             `json:"content,omitempty"`
-		User      UserSimpleResponse `json:"user,omitempty"`
-		CreatedAt string             `json:"created_at"`
-		UpdatedAt string             `json:"updated_at"`
+		ID            uint                 `json:"id"`
+		Title         string               `json:"title"`
+		Slug          string               `json:"slug"`
+		Content       string               `json:"content,omitempty"`
+		ContentFormat string               `json:"content_format,omitempty"`
+		ContentHTML   string               `json:"content_html,omitempty"`
+		Images        *ImagesResponse      `json:"images,omitempty"`
+		Media         *MediaSimpleResponse `json:"media,omitempty"`
+		User          UserSimpleResponse   `json:"user,omitempty"`
+		SEO           *SEOResponse         `json:"seo,omitempty"`
+		CreatedAt     string               `json:"created_at"`
+		UpdatedAt     string               `json:"updated_at"`
 	}
 )
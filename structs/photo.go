@@ -4,16 +4,28 @@ type (
 	PhotoCreateRequest struct {
 		Caption     string `json:"caption" binding:"required"`
 		Description string `json:"description" binding:"required"`
+		AlbumID     *uint  `json:"album_id" form:"album_id"`
+		SortOrder   int    `json:"sort_order" form:"sort_order"`
+	}
+
+	PhotoUpdateRequest struct {
+		Caption     string `json:"caption" binding:"required"`
+		Description string `json:"description" binding:"required"`
+		AlbumID     *uint  `json:"album_id"`
+		SortOrder   int    `json:"sort_order"`
 	}
 )
 
 type (
 	PhotoResponse struct {
-		ID          uint   `json:"id"`
-		Image       string `json:"image"`
-		Caption     string `json:"caption"`
-		Description string `json:"description"`
-		CreatedAt   string `json:"created_at"`
-		UpdatedAt   string `json:"updated_at"`
+		ID          uint            `json:"id"`
+		Image       string          `json:"image"`
+		Images      *ImagesResponse `json:"images,omitempty"`
+		Caption     string          `json:"caption"`
+		Description string          `json:"description"`
+		AlbumID     *uint           `json:"album_id,omitempty"`
+		SortOrder   int             `json:"sort_order"`
+		CreatedAt   string          `json:"created_at"`
+		UpdatedAt   string          `json:"updated_at"`
 	}
 )
@@ -2,40 +2,77 @@ package structs
 
 type (
 	PostCreateRequest struct {
-		Title      string `json:"title" binding:"required"`
-		Content    string `json:"content" binding:"required"`
-		CategoryID uint   `json:"category_id" binding:"required"`
+		SEORequest
+
+		MediaID       *uint    `json:"media_id" form:"media_id"`
+		Title         string   `json:"title" binding:"required"`
+		Content       string   `json:"content" binding:"required"`
+		ContentFormat string   `json:"content_format" binding:"omitempty,oneof=html markdown"`
+		CategoryID    uint     `json:"category_id" binding:"required"`
+		Status        string   `json:"status" binding:"omitempty,oneof=draft review published archived"`
+		PublishedAt   string   `json:"published_at" binding:"omitempty,datetime=2006-01-02 15:04:05"`
+		Tags          []string `json:"tags"`
 	}
 
 	PostUpdateRequest struct {
-		Title      string `json:"title" binding:"required"`
-		Content    string `json:"content" binding:"required"`
-		CategoryID uint   `json:"category_id" binding:"required"`
+		SEORequest
+
+		MediaID       *uint    `json:"media_id" form:"media_id"`
+		Title         string   `json:"title" binding:"required"`
+		Content       string   `json:"content" binding:"required"`
+		ContentFormat string   `json:"content_format" binding:"omitempty,oneof=html markdown"`
+		CategoryID    uint     `json:"category_id" binding:"required"`
+		Status        string   `json:"status" binding:"omitempty,oneof=draft review published archived"`
+		PublishedAt   string   `json:"published_at" binding:"omitempty,datetime=2006-01-02 15:04:05"`
+		Tags          []string `json:"tags"`
 	}
 )
 
 type (
 	PostResponse struct {
-		ID         uint   `json:"id"`
-		Image      string `json:"image"`
-		Title      string `json:"title"`
-		Slug       string `json:"slug"`
-		Content    string `json:"content"`
-		CategoryID uint   `json:"category_id"`
-		UserID     uint   `json:"user_id"`
-		CreatedAt  string `json:"created_at"`
-		UpdatedAt  string `json:"updated_at"`
+		ID            uint                 `json:"id"`
+		Image         string               `json:"image"`
+		Images        *ImagesResponse      `json:"images,omitempty"`
+		Media         *MediaSimpleResponse `json:"media,omitempty"`
+		Title         string               `json:"title"`
+		Slug          string               `json:"slug"`
+		Content       string               `json:"content"`
+		ContentFormat string               `json:"content_format"`
+		ContentHTML   string               `json:"content_html"`
+		Status        string               `json:"status"`
+		PublishedAt   string               `json:"published_at"`
+		CategoryID    uint                 `json:"category_id"`
+		UserID        uint                 `json:"user_id"`
+		Tags          []TagSimpleResponse  `json:"tags"`
+		SEO           *SEOResponse         `json:"seo,omitempty"`
+		CreatedAt     string               `json:"created_at"`
+		UpdatedAt     string               `json:"updated_at"`
 	}
 
 	PostWithRelationResponse struct {
-		ID        uint                   `json:"id"`
-		Image     string                 `json:"image"`
-		Title     string                 `json:"title"`
-		Slug      string                 `json:"slug"`
-		Content   string                 `json:"content,omitempty"`
-		Category  CategorySimpleResponse `json:"category,omitempty"`
-		User      UserSimpleResponse     `json:"user,omitempty"`
-		CreatedAt string                 `json:"created_at"`
-		UpdatedAt string                 `json:"updated_at"`
+		ID            uint                   `json:"id"`
+		Image         string                 `json:"image"`
+		Images        *ImagesResponse        `json:"images,omitempty"`
+		Media         *MediaSimpleResponse   `json:"media,omitempty"`
+		Title         string                 `json:"title"`
+		Slug          string                 `json:"slug"`
+		Content       string                 `json:"content,omitempty"`
+		ContentFormat string                 `json:"content_format,omitempty"`
+		ContentHTML   string                 `json:"content_html,omitempty"`
+		Status        string                 `json:"status,omitempty"`
+		PublishedAt   string                 `json:"published_at"`
+		Category      CategorySimpleResponse `json:"category,omitempty"`
+		User          UserSimpleResponse     `json:"user,omitempty"`
+		Tags          []TagSimpleResponse    `json:"tags"`
+		Comments      []CommentResponse      `json:"comments,omitempty"`
+		SEO           *SEOResponse           `json:"seo,omitempty"`
+		CreatedAt     string                 `json:"created_at"`
+		UpdatedAt     string                 `json:"updated_at"`
+	}
+
+	PostSimpleResponse struct {
+		ID    uint   `json:"id"`
+		Title string `json:"title"`
+		Slug  string `json:"slug"`
 	}
 )
@@ -2,6 +2,9 @@ package structs
 
 type (
 	ProductCreateRequest struct {
+		SEORequest
+
+		MediaID *uint  `json:"media_id" form:"media_id"`
 		Title   string `json:"title" binding:"required"`
 		Content string `json:"content" binding:"required"`
 		Owner   string `json:"owner" binding:"required"`
@@ -11,6 +14,9 @@ type (
 	}
 
 	ProductUpdateRequest struct {
+		SEORequest
+
+		MediaID *uint  `json:"media_id" form:"media_id"`
 		Title   string `json:"title" binding:"required"`
 		Content string `json:"content" binding:"required"`
 		Owner   string `json:"owner" binding:"required"`
@@ -22,31 +28,37 @@ type (
 
 type (
 	ProductResponse struct {
-		ID        uint   `json:"id"`
-		Title     string `json:"title"`
-		Slug      string `json:"slug"`
-		Content   string `json:"content"`
-		Image     string `json:"image"`
-		Owner     string `json:"owner"`
-		Price     int    `json:"price"`
-		Phone     string `json:"phone"`
-		Address   string `json:"address"`
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ID        uint                 `json:"id"`
+		Title     string               `json:"title"`
+		Slug      string               `json:"slug"`
+		Content   string               `json:"content"`
+		Image     string               `json:"image"`
+		Images    *ImagesResponse      `json:"images,omitempty"`
+		Media     *MediaSimpleResponse `json:"media,omitempty"`
+		Owner     string               `json:"owner"`
+		Price     int                  `json:"price"`
+		Phone     string               `json:"phone"`
+		Address   string               `json:"address"`
+		SEO       *SEOResponse         `json:"seo,omitempty"`
+		CreatedAt string               `json:"created_at"`
+		UpdatedAt string               `json:"updated_at"`
 	}
 
 	ProductWithRelationResponse struct {
-		ID        uint               `json:"id"`
-		Title     string             `json:"title"`
-		Slug      string             `json:"slug"`
-		Content   string             `json:"content,omitempty"`
-		Image     string             `json:"image,omitempty"`
-		Owner     string             `json:"owner"`
-		Price     int                `json:"price,omitempty"`
-		Phone     string             `json:"phone,omitempty"`
-		Address   string             `json:"address,omitempty"`
-		User      UserSimpleResponse `json:"user,omitempty"`
-		CreatedAt string             `json:"created_at"`
-		UpdatedAt string             `json:"updated_at"`
+		ID        uint                 `json:"id"`
+		Title     string               `json:"title"`
+		Slug      string               `json:"slug"`
+		Content   string               `json:"content,omitempty"`
+		Image     string               `json:"image,omitempty"`
+		Images    *ImagesResponse      `json:"images,omitempty"`
+		Media     *MediaSimpleResponse `json:"media,omitempty"`
+		Owner     string               `json:"owner"`
+		Price     int                  `json:"price,omitempty"`
+		Phone     string               `json:"phone,omitempty"`
+		Address   string               `json:"address,omitempty"`
+		User      UserSimpleResponse   `json:"user,omitempty"`
+		SEO       *SEOResponse         `json:"seo,omitempty"`
+		CreatedAt string               `json:"created_at"`
+		UpdatedAt string               `json:"updated_at"`
 	}
 )
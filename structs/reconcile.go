@@ -0,0 +1,34 @@
+package structs
+
+type (
+	ReconcileRequest struct {
+		// Mode is dry-run (the default), delete or quarantine.
+		Mode string `json:"mode" binding:"omitempty,oneof=dry-run delete quarantine"`
+	}
+)
+
+type (
+	ReconcileFileResponse struct {
+		Key     string `json:"key"`
+		Size    int64  `json:"size"`
+		ModTime string `json:"mod_time"`
+		// Action is what happened to an orphan: kept, deleted or the key
+		// it was quarantined under.
+		Action string `json:"action,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	ReconcileMissingResponse struct {
+		Key        string `json:"key"`
+		EntityType string `json:"entity_type"`
+		EntityID   uint   `json:"entity_id"`
+	}
+
+	ReconcileResponse struct {
+		Mode        string                     `json:"mode"`
+		Scanned     int                        `json:"scanned"`
+		Orphans     []ReconcileFileResponse    `json:"orphans"`
+		OrphanBytes int64                      `json:"orphan_bytes"`
+		Missing     []ReconcileMissingResponse `json:"missing"`
+	}
+)
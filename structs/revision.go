@@ -0,0 +1,33 @@
+package structs
+
+type (
+	RevisionResponse struct {
+		ID            uint               `json:"id"`
+		EntityType    string             `json:"entity_type"`
+		EntityID      uint               `json:"entity_id"`
+		Title         string             `json:"title"`
+		Content       string             `json:"content,omitempty"`
+		ContentFormat string             `json:"content_format,omitempty"`
+		Image         string             `json:"image,omitempty"`
+		MediaID       *uint              `json:"media_id,omitempty"`
+		CategoryID    *uint              `json:"category_id,omitempty"`
+		User          UserSimpleResponse `json:"user"`
+		CreatedAt     string             `json:"created_at"`
+	}
+
+	RevisionFieldDiff struct {
+		Changed bool       `json:"changed"`
+		Lines   []DiffLine `json:"lines"`
+	}
+
+	DiffLine struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	}
+
+	RevisionDiffResponse struct {
+		From   RevisionResponse             `json:"from"`
+		To     RevisionResponse             `json:"to"`
+		Fields map[string]RevisionFieldDiff `json:"fields"`
+	}
+)
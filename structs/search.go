@@ -0,0 +1,14 @@
+package structs
+
+type (
+	SearchResultResponse struct {
+		Type      string  `json:"type"`
+		ID        uint    `json:"id"`
+		Title     string  `json:"title"`
+		Slug      string  `json:"slug"`
+		Image     string  `json:"image"`
+		Snippet   string  `json:"snippet"`
+		Rank      float64 `json:"rank"`
+		UpdatedAt string  `json:"updated_at"`
+	}
+)
@@ -0,0 +1,20 @@
+package structs
+
+type (
+	SEORequest struct {
+		MetaTitle       string `json:"meta_title" binding:"omitempty,max=70"`
+		MetaDescription string `json:"meta_description" binding:"omitempty,max=160"`
+		CanonicalURL    string `json:"canonical_url" binding:"omitempty,url"`
+		OGImage         string `json:"og_image" binding:"omitempty,url"`
+	}
+)
+
+type (
+	SEOResponse struct {
+		MetaTitle       string `json:"meta_title"`
+		MetaDescription string `json:"meta_description"`
+		CanonicalURL    string `json:"canonical_url"`
+		OGImage         string `json:"og_image"`
+		OGType          string `json:"og_type,omitempty"`
+	}
+)
@@ -0,0 +1,27 @@
+package structs
+
+import "encoding/xml"
+
+type (
+	SitemapURLSet struct {
+		XMLName xml.Name     `xml:"urlset"`
+		XMLNS   string       `xml:"xmlns,attr"`
+		URLs    []SitemapURL `xml:"url"`
+	}
+
+	SitemapURL struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+
+	SitemapIndex struct {
+		XMLName  xml.Name         `xml:"sitemapindex"`
+		XMLNS    string           `xml:"xmlns,attr"`
+		Sitemaps []SitemapPointer `xml:"sitemap"`
+	}
+
+	SitemapPointer struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+)
@@ -2,16 +2,19 @@ package structs
 
 type (
 	SliderCreateRequest struct {
+		MediaID     *uint  `json:"media_id" form:"media_id"`
 		Description string `json:"description" binding:"required"`
 	}
 )
 
 type (
 	SliderResponse struct {
-		ID          uint   `json:"id"`
-		Image       string `json:"image"`
-		Description string `json:"description"`
-		CreatedAt   string `json:"created_at"`
-		UpdatedAt   string `json:"updated_at"`
+		ID          uint                 `json:"id"`
+		Image       string               `json:"image"`
+		Images      *ImagesResponse      `json:"images,omitempty"`
+		Media       *MediaSimpleResponse `json:"media,omitempty"`
+		Description string               `json:"description"`
+		CreatedAt   string               `json:"created_at"`
+		UpdatedAt   string               `json:"updated_at"`
 	}
 )
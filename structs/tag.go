@@ -0,0 +1,28 @@
+package structs
+
+type (
+	TagCreateRequest struct {
+		Name string `json:"name" binding:"required"`
+	}
+
+	TagUpdateRequest struct {
+		Name string `json:"name" binding:"required"`
+	}
+)
+
+type (
+	TagResponse struct {
+		ID         uint   `json:"id"`
+		Name       string `json:"name"`
+		Slug       string `json:"slug"`
+		PostsCount int64  `json:"posts_count"`
+		CreatedAt  string `json:"created_at,omitempty"`
+		UpdatedAt  string `json:"updated_at,omitempty"`
+	}
+
+	TagSimpleResponse struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+		Slug string `json:"slug"`
+	}
+)
@@ -0,0 +1,14 @@
+package structs
+
+type (
+	TrashResponse struct {
+		ID        uint            `json:"id"`
+		Title     string          `json:"title"`
+		Image     string          `json:"image,omitempty"`
+		Images    *ImagesResponse `json:"images,omitempty"`
+		DeletedAt string          `json:"deleted_at"`
+		// PurgeAt is when the item is purged automatically, empty when
+		// trashed items are kept.
+		PurgeAt string `json:"purge_at,omitempty"`
+	}
+)
@@ -0,0 +1,50 @@
+package structs
+
+type (
+	TwoFactorCodeRequest struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	TwoFactorDisableRequest struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+
+	TwoFactorLoginRequest struct {
+		ChallengeToken string `json:"challenge_token" binding:"required"`
+		Code           string `json:"code"`
+		RecoveryCode   string `json:"recovery_code"`
+	}
+
+	TwoFactorPolicyRequest struct {
+		Required *bool `json:"required" binding:"required"`
+	}
+)
+
+type (
+	TwoFactorStatusResponse struct {
+		Enabled                bool   `json:"enabled"`
+		Required               bool   `json:"required"`
+		ConfirmedAt            string `json:"confirmed_at,omitempty"`
+		RecoveryCodesRemaining int64  `json:"recovery_codes_remaining"`
+	}
+
+	TwoFactorSetupResponse struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioning_uri"`
+	}
+
+	TwoFactorRecoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+
+	TwoFactorChallengeResponse struct {
+		TwoFactorRequired bool   `json:"two_factor_required"`
+		ChallengeToken    string `json:"challenge_token"`
+		ExpiresAt         string `json:"expires_at"`
+	}
+
+	TwoFactorPolicyResponse struct {
+		Required bool `json:"required"`
+	}
+)
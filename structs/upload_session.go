@@ -0,0 +1,25 @@
+package structs
+
+type (
+	UploadSessionCreateRequest struct {
+		FileName string `json:"file_name" binding:"required,max=255"`
+		Size     int64  `json:"size" binding:"required,min=1"`
+		// Checksum is the sha256 of the whole file in hex, verified once
+		// the last chunk arrives.
+		Checksum string `json:"checksum" binding:"omitempty,len=64,hexadecimal"`
+	}
+)
+
+type (
+	UploadSessionResponse struct {
+		ID          string `json:"id"`
+		FileName    string `json:"file_name"`
+		Size        int64  `json:"size"`
+		Offset      int64  `json:"offset"`
+		Checksum    string `json:"checksum,omitempty"`
+		Status      string `json:"status"`
+		ExpiresAt   string `json:"expires_at"`
+		CompletedAt string `json:"completed_at,omitempty"`
+		CreatedAt   string `json:"created_at"`
+	}
+)
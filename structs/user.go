@@ -25,15 +25,17 @@ type (
 
 type (
 	UserResponse struct {
-		ID          uint            `json:"id"`
-		Name        string          `json:"name"`
-		Username    string          `json:"username"`
-		Email       string          `json:"email"`
-		Permissions map[string]bool `json:"permissions,omitempty"`
-		Roles       []RoleResponse  `json:"roles,omitempty"`
-		Token       *string         `json:"token,omitempty"`
-		CreatedAt   string          `json:"created_at,omitempty"`
-		UpdatedAt   string          `json:"updated_at,omitempty"`
+		ID                     uint            `json:"id"`
+		Name                   string          `json:"name"`
+		Username               string          `json:"username"`
+		Email                  string          `json:"email"`
+		Permissions            map[string]bool `json:"permissions,omitempty"`
+		Roles                  []RoleResponse  `json:"roles,omitempty"`
+		Token                  *string         `json:"token,omitempty"`
+		Tokens                 *TokenResponse  `json:"tokens,omitempty"`
+		TwoFactorSetupRequired bool            `json:"two_factor_setup_required,omitempty"`
+		CreatedAt              string          `json:"created_at,omitempty"`
+		UpdatedAt              string          `json:"updated_at,omitempty"`
 	}
 
 	UserSimpleResponse struct {